@@ -0,0 +1,82 @@
+// Package codec controls how the elements of the collections in this
+// module are encoded by their MarshalBinary and GobEncode methods.
+//
+// The binary format of a collection is versioned and length-prefixed.
+// Elements of the fixed-size numeric types (int8 to int64, uint8 to
+// uint64, int, uint, float32, float64) and bool are written in a compact
+// fixed-width form, while strings and byte slices are written as
+// length-prefixed bytes. Types defined from these, such as `type ID int64`,
+// are not covered. Types implementing encoding.BinaryMarshaler, whose
+// pointer implements encoding.BinaryUnmarshaler, are handled through
+// those methods. For any other element type, register a Codec with
+// Register before encoding or decoding a collection of that type.
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// Codec encodes and decodes single values of type T for the binary
+// format of the collections.
+type Codec[T any] interface {
+	// AppendBinary appends the encoding of v to b and returns the
+	// extended buffer.
+	AppendBinary(b []byte, v T) ([]byte, error)
+
+	// DecodeBinary decodes a value from data, which holds exactly one
+	// value as encoded by AppendBinary. It must not retain data.
+	DecodeBinary(data []byte) (T, error)
+}
+
+// Funcs is a Codec built from a pair of functions.
+type Funcs[T any] struct {
+	Append func(b []byte, v T) ([]byte, error)
+	Decode func(data []byte) (T, error)
+}
+
+// AppendBinary calls f.Append.
+func (f Funcs[T]) AppendBinary(b []byte, v T) ([]byte, error) {
+	return f.Append(b, v)
+}
+
+// DecodeBinary calls f.Decode.
+func (f Funcs[T]) DecodeBinary(data []byte) (T, error) {
+	return f.Decode(data)
+}
+
+var (
+	// ErrNoCodec is returned when encoding or decoding a collection whose
+	// element type has no built-in encoding and no registered Codec.
+	ErrNoCodec = errors.New("codec: no codec for element type")
+
+	// ErrVersion is returned when decoding data written in a format
+	// version this package does not understand.
+	ErrVersion = errors.New("codec: unsupported format version")
+
+	// ErrCorrupt is returned when decoding data that is truncated,
+	// malformed or was written for a different collection or element type.
+	ErrCorrupt = errors.New("codec: malformed data")
+)
+
+// registry maps the reflect.Type of T to its registered Codec[T].
+var registry sync.Map
+
+// Register makes c the codec for elements of type T, replacing any codec
+// registered earlier for T. A registered codec takes precedence over the
+// built-in encodings, so it must be registered identically by the program
+// that encodes and the one that decodes. Register is safe for concurrent
+// use, but it is normally called from an init function.
+func Register[T any](c Codec[T]) {
+	registry.Store(reflect.TypeFor[T](), c)
+}
+
+// Lookup returns the codec registered for elements of type T, if any.
+func Lookup[T any]() (Codec[T], bool) {
+	c, ok := registry.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, false
+	}
+	return c.(Codec[T]), true
+}
@@ -0,0 +1,49 @@
+package codec_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/khavishbhundoo/collections/codec"
+	"github.com/khavishbhundoo/collections/queue"
+)
+
+type point struct{ X, Y int32 }
+
+func ExampleRegister() {
+	// point has no built-in encoding, so register one before
+	// encoding a collection of points
+	codec.Register[point](codec.Funcs[point]{
+		Append: func(b []byte, p point) ([]byte, error) {
+			b = binary.AppendVarint(b, int64(p.X))
+			return binary.AppendVarint(b, int64(p.Y)), nil
+		},
+		Decode: func(data []byte) (point, error) {
+			x, n := binary.Varint(data)
+			y, m := binary.Varint(data[max(n, 0):])
+			if n <= 0 || m <= 0 {
+				return point{}, errors.New("invalid point")
+			}
+			return point{int32(x), int32(y)}, nil
+		},
+	})
+
+	q := queue.New[point]()
+	q.PushMany(point{1, 2}, point{-3, 4})
+	data, err := q.MarshalBinary()
+	fmt.Println(len(data), err)
+
+	var decoded queue.Queue[point]
+	err = decoded.UnmarshalBinary(data)
+	for p := range decoded.All() {
+		fmt.Println(p)
+	}
+	fmt.Println(err)
+
+	// Output:
+	// 10 <nil>
+	// {1 2}
+	// {-3 4}
+	// <nil>
+}
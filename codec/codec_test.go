@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+)
+
+type point struct{ X, Y int8 }
+
+func TestRegister(t *testing.T) {
+	if _, ok := Lookup[point](); ok {
+		t.Fatalf("Lookup() before Register(): expected no codec")
+	}
+
+	errOdd := errors.New("odd length")
+	Register[point](Funcs[point]{
+		Append: func(b []byte, p point) ([]byte, error) {
+			return append(b, byte(p.X), byte(p.Y)), nil
+		},
+		Decode: func(data []byte) (point, error) {
+			if len(data) != 2 {
+				return point{}, errOdd
+			}
+			return point{int8(data[0]), int8(data[1])}, nil
+		},
+	})
+
+	c, ok := Lookup[point]()
+	if !ok {
+		t.Fatalf("Lookup() after Register(): expected a codec")
+	}
+	data, err := c.AppendBinary(nil, point{1, -1})
+	if err != nil {
+		t.Fatalf("AppendBinary(): unexpected error %v", err)
+	}
+	if p, err := c.DecodeBinary(data); err != nil || p != (point{1, -1}) {
+		t.Errorf("DecodeBinary(): expected %v, got %v (err=%v)", point{1, -1}, p, err)
+	}
+	if _, err := c.DecodeBinary(data[:1]); !errors.Is(err, errOdd) {
+		t.Errorf("DecodeBinary(): expected %v, got %v", errOdd, err)
+	}
+
+	// Codecs are registered per type
+	if _, ok := Lookup[*point](); ok {
+		t.Errorf("Lookup[*point](): expected no codec")
+	}
+}
@@ -0,0 +1,47 @@
+package cmap
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The entries are
+// written in unspecified order in a compact versioned format, from a
+// snapshot taken under the read lock. Expired entries are left out and
+// expiration times are not recorded.
+// See the collections/codec package for how keys and values are encoded;
+// types without a built-in encoding need a registered codec.
+//
+// Because CMap must not be copied, only a *CMap implements
+// encoding.BinaryMarshaler and gob.GobEncoder.
+func (c *CMap[K, V]) MarshalBinary() ([]byte, error) {
+	keys, values := c.snapshot()
+	return binenc.AppendMap(nil, keys, values)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the map with data encoded by MarshalBinary. The new entries
+// expire after the map's default TTL, if one was configured, and the
+// entries they replace are reported to OnEvict as Deleted or Expired.
+// On error the map is not modified.
+func (c *CMap[K, V]) UnmarshalBinary(data []byte) error {
+	keys, values, err := binenc.DecodeMap[K, V](data)
+	if err != nil {
+		return err
+	}
+	c.replace(func(yield func(K, V) bool) {
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}, len(keys))
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (c *CMap[K, V]) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (c *CMap[K, V]) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
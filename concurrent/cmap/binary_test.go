@@ -0,0 +1,80 @@
+package cmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+func TestCMap_Binary(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewWithOptions(Options[string, int]{Now: clock.Now})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.SetWithTTL("expired", 3, time.Second)
+	clock.Advance(2 * time.Second)
+
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+
+	var log evictionLog
+	decoded := NewWithOptions(Options[string, int]{OnEvict: log.OnEvict})
+	decoded.Set("old", 9)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): unexpected error %v", err)
+	}
+	if got := slices.Sorted(slices.Values(decoded.Keys())); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("UnmarshalBinary(): expected keys %v, got %v", []string{"a", "b"}, got)
+	}
+	if v, ok := decoded.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b): expected %d, got %d", 2, v)
+	}
+	if got := log.Events(); !slices.Equal(got, []string{"old:deleted"}) {
+		t.Errorf("OnEvict: expected %v, got %v", []string{"old:deleted"}, got)
+	}
+
+	// Invalid data leaves the map untouched
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("UnmarshalBinary() of truncated data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+	if decoded.Len() != 2 {
+		t.Errorf("Len(): expected %d, got %d", 2, decoded.Len())
+	}
+}
+
+func TestCMap_Gob(t *testing.T) {
+	type checkpoint struct {
+		Name   string
+		Counts *CMap[string, int64]
+	}
+	in := checkpoint{Name: "cp", Counts: New[string, int64]()}
+	in.Counts.Set("a", 1)
+	in.Counts.Set("b", 2)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode(): unexpected error %v", err)
+	}
+	var out checkpoint
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode(): unexpected error %v", err)
+	}
+	if out.Name != "cp" {
+		t.Errorf("Decode(): expected name %q, got %q", "cp", out.Name)
+	}
+	if got := slices.Sorted(slices.Values(out.Counts.Keys())); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Decode(): expected keys %v, got %v", []string{"a", "b"}, got)
+	}
+	for k, want := range map[string]int64{"a": 1, "b": 2} {
+		if v, ok := out.Counts.Get(k); !ok || v != want {
+			t.Errorf("Get(%s): expected %d, got %d", k, want, v)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package cmap
 
-import "sync"
+import (
+	"iter"
+	"sync"
+	"time"
+)
 
 // CMap is a generic, thread-safe key-value store with optional capacity hints.
 // The implementation uses an underlying map protected by a sync.RWMutex.
@@ -9,10 +13,23 @@ import "sync"
 // Use New() or NewWithCapacity() if you prefer an explicit constructor
 // or want to set an initial capacity. All operations are safe for
 // concurrent use by multiple goroutines.
+//
+// Entries may be given a time to live with SetWithTTL, or through a
+// map-wide default set with NewWithOptions. Expired entries are invisible
+// to every read, including Get, Contains, Len, Keys and the iterators.
+//
+// A CMap encodes to and from JSON as an object, like a Go map, so only
+// maps whose keys are strings, integers or encoding.TextMarshalers can
+// be marshalled; see MarshalJSON.
 type CMap[K comparable, V any] struct {
 	_               noCopy // prevents copying after first use
 	items           map[K]V
+	expires         map[K]int64 // expiration in Unix nanoseconds; only entries with a TTL
 	initialCapacity int
+	defaultTTL      time.Duration
+	onEvict         func(key K, value V, reason EvictionReason)
+	now             func() time.Time
+	stop            chan struct{} // closed by Close to stop the sweeper
 	mu              sync.RWMutex
 }
 
@@ -35,15 +52,26 @@ func NewWithCapacity[K comparable, V any](capacity int) *CMap[K, V] {
 	}
 }
 
+// FromSeq returns a CMap holding the key-value pairs of seq.
+// If a key is produced more than once, the last value wins.
+// maps.Collect(c.All()) does the reverse.
+func FromSeq[K comparable, V any](seq iter.Seq2[K, V]) *CMap[K, V] {
+	c := New[K, V]()
+	for k, v := range seq {
+		c.Set(k, v)
+	}
+	return c
+}
+
 // Set associates value with key, creating the map if necessary.
-// If key already exists, its value is replaced.
+// If key already exists, its value is replaced. The entry expires
+// after the map's default TTL, if one was configured.
 func (c *CMap[K, V]) Set(key K, value V) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.items == nil {
-		c.items = make(map[K]V, c.initialCapacity)
-	}
-	c.items[key] = value
+	ev = c.store(key, value, c.deadline(c.defaultTTL))
 }
 
 // Get returns the value for key and reports whether it was present.
@@ -51,67 +79,268 @@ func (c *CMap[K, V]) Set(key K, value V) {
 func (c *CMap[K, V]) Get(key K) (V, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	if c.items == nil {
-		var zero V
-		return zero, false
-	}
-	val, ok := c.items[key]
-	return val, ok
+	return c.lookup(key)
 }
 
 // Delete removes key and its value, if present.
 // It does nothing if the key is not in the map.
 func (c *CMap[K, V]) Delete(key K) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.items, key)
+	ev = c.remove(key)
 }
 
 // Contains reports whether key exists in the map.
 func (c *CMap[K, V]) Contains(key K) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	_, ok := c.items[key]
+	_, ok := c.lookup(key)
 	return ok
 }
 
-// Len returns the number of entries in the map.
+// Len returns the number of entries in the map, not counting expired ones.
+// While entries with a TTL are present, Len runs in time proportional
+// to their number.
 func (c *CMap[K, V]) Len() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return len(c.items)
+	n := len(c.items)
+	if len(c.expires) > 0 {
+		now := c.clock().UnixNano()
+		for _, deadline := range c.expires {
+			if now >= deadline {
+				n--
+			}
+		}
+	}
+	return n
 }
 
 // Keys returns a snapshot of all keys in the map.
 // The returned slice does not reflect later modifications.
 func (c *CMap[K, V]) Keys() []K {
+	keys, _ := c.snapshot()
+	return keys
+}
+
+// GetOrSet returns the existing value for key if present, with loaded set
+// to true. Otherwise it stores value and returns it, with loaded set to false.
+// The lookup and the store happen atomically under the write lock.
+func (c *CMap[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.lookup(key); ok {
+		return existing, true
+	}
+	ev = c.store(key, value, c.deadline(c.defaultTTL))
+	return value, false
+}
+
+// SetIfAbsent stores value for key only if key is not already present.
+// It reports whether the value was stored.
+func (c *CMap[K, V]) SetIfAbsent(key K, value V) bool {
+	_, loaded := c.GetOrSet(key, value)
+	return !loaded
+}
+
+// Swap stores value for key and returns the previous value, if any.
+// The loaded result reports whether key was present.
+func (c *CMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous, loaded = c.lookup(key)
+	ev = c.store(key, value, c.deadline(c.defaultTTL))
+	return previous, loaded
+}
+
+// GetAndDelete removes key and returns the value it held, if any.
+// The loaded result reports whether key was present.
+func (c *CMap[K, V]) GetAndDelete(key K) (value V, loaded bool) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, loaded = c.lookup(key)
+	ev = c.remove(key)
+	return value, loaded
+}
+
+// ComputeOp tells Compute what to do with the entry after the callback returns.
+type ComputeOp int
+
+const (
+	// ComputeKeep leaves the entry as it was: an existing value is kept
+	// and an absent key stays absent. The value returned by the callback is ignored.
+	ComputeKeep ComputeOp = iota
+	// ComputeReplace stores the value returned by the callback,
+	// inserting the key if it was absent.
+	ComputeReplace
+	// ComputeDelete removes the entry if it exists.
+	ComputeDelete
+)
+
+// Compute atomically reads, modifies or deletes the entry for key.
+//
+// fn receives the current value and whether key is present, and returns
+// a new value together with a ComputeOp saying whether to keep the entry
+// unchanged, replace it with the new value or delete it. fn runs while
+// the write lock is held, so it must not call other methods of c.
+//
+// Compute returns the value associated with key after the operation and
+// whether key is present.
+func (c *CMap[K, V]) Compute(key K, fn func(old V, ok bool) (V, ComputeOp)) (actual V, present bool) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, ok := c.lookup(key)
+	value, op := fn(old, ok)
+	switch op {
+	case ComputeReplace:
+		ev = c.store(key, value, c.deadline(c.defaultTTL))
+		return value, true
+	case ComputeDelete:
+		ev = c.remove(key)
+		var zero V
+		return zero, false
+	default:
+		return old, ok
+	}
+}
+
+// CompareAndSwap stores new for key if the value currently held for key
+// equals old, and reports whether the swap happened. It is a function
+// rather than a method because it needs V to be comparable.
+func CompareAndSwap[K, V comparable](c *CMap[K, V], key K, old, new V) bool {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if current, ok := c.lookup(key); !ok || current != old {
+		return false
+	}
+	ev = c.store(key, new, c.deadline(c.defaultTTL))
+	return true
+}
+
+// CompareAndDelete removes key if the value currently held for key
+// equals old, and reports whether the entry was deleted. It is a function
+// rather than a method because it needs V to be comparable.
+func CompareAndDelete[K, V comparable](c *CMap[K, V], key K, old V) bool {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if current, ok := c.lookup(key); !ok || current != old {
+		return false
+	}
+	ev = c.remove(key)
+	return true
+}
+
+// All returns an iterator over the key-value pairs of the map.
+// The iteration order is not specified.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the map; such changes are not
+// seen by the ongoing iteration.
+func (c *CMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := c.snapshot()
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map.
+// Like All, it runs over a snapshot and does not hold the lock
+// while the loop body runs.
+func (c *CMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		_, values := c.snapshot()
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns copies of the keys and values of the map,
+// where values[i] is the value associated with keys[i].
+// Expired entries are left out.
+func (c *CMap[K, V]) snapshot() (keys []K, values []V) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	keys := make([]K, 0, len(c.items))
-	for k := range c.items {
+	keys = make([]K, 0, len(c.items))
+	values = make([]V, 0, len(c.items))
+	var now int64
+	if len(c.expires) > 0 {
+		now = c.clock().UnixNano()
+	}
+	for k, v := range c.items {
+		if deadline, ok := c.expires[k]; ok && now >= deadline {
+			continue
+		}
 		keys = append(keys, k)
+		values = append(values, v)
 	}
-	return keys
+	return keys, values
 }
 
 // Reset removes all entries while keeping the current allocation.
 // Use Reset to reuse the map without triggering new allocations.
 func (c *CMap[K, V]) Reset() {
+	var evs []eviction[K, V]
+	defer c.notifyAll(&evs)
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.items == nil {
 		c.items = make(map[K]V, c.initialCapacity)
 		return
 	}
+	evs = c.removeAll()
 	clear(c.items)
+	clear(c.expires)
 }
 
 // Clear removes all entries and allocates a new underlying map.
 // Unlike Reset, Clear releases the old allocation to the runtime.
 func (c *CMap[K, V]) Clear() {
+	var evs []eviction[K, V]
+	defer c.notifyAll(&evs)
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	evs = c.removeAll()
 	c.items = make(map[K]V, c.initialCapacity)
+	c.expires = nil
+}
+
+// replace makes the n key-value pairs of entries the contents of the map.
+// The new entries expire after the default TTL, if one was configured,
+// and the entries they replace are reported to OnEvict.
+func (c *CMap[K, V]) replace(entries iter.Seq2[K, V], n int) {
+	var evs []eviction[K, V]
+	defer c.notifyAll(&evs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	evs = c.removeAll()
+	c.items = make(map[K]V, max(c.initialCapacity, n))
+	c.expires = nil
+	deadline := c.deadline(c.defaultTTL)
+	for k, v := range entries {
+		c.store(k, v, deadline)
+	}
 }
 
 // noCopy may be added to structs which must not be copied
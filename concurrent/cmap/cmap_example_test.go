@@ -2,6 +2,8 @@ package cmap_test
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 
@@ -92,3 +94,21 @@ func ExampleCMap_concurrent() {
 	// Final state of the map
 	fmt.Println("Final map length:", m.Len())
 }
+
+func ExampleCMap_All() {
+	m := cmap.FromSeq(maps.All(map[string]int{"Go": 1, "C#": 2}))
+
+	// All iterates over a snapshot, so the map may be modified in the loop
+	for k, v := range m.All() {
+		m.Set(k, v*10)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(maps.Collect(m.All()))) {
+		v, _ := m.Get(k)
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// C# 20
+	// Go 10
+}
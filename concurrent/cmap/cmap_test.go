@@ -1,6 +1,8 @@
 package cmap
 
 import (
+	"maps"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -117,3 +119,186 @@ func TestCMap_ZeroValue(t *testing.T) {
 		t.Errorf("expected length 1, got %d", l)
 	}
 }
+
+func TestCMap_All(t *testing.T) {
+	m := New[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	got := maps.Collect(m.All())
+	if !maps.Equal(got, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("All(): expected %v, got %v", map[string]int{"a": 1, "b": 2}, got)
+	}
+
+	values := slices.Sorted(m.Values())
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Values(): expected %v, got %v", []int{1, 2}, values)
+	}
+
+	// The loop body may modify the map without deadlocking,
+	// and the iteration is not affected by the modifications.
+	n := 0
+	for k, v := range m.All() {
+		m.Delete(k)
+		m.Set(k+k, v)
+		n++
+	}
+	if n != 2 {
+		t.Errorf("All(): expected %d pairs, got %d", 2, n)
+	}
+	if !m.Contains("aa") || m.Contains("a") {
+		t.Errorf("expected the map to reflect changes made during iteration")
+	}
+
+	var zero CMap[string, int]
+	for k := range zero.All() {
+		t.Errorf("All() on zero-value map: unexpected %q", k)
+	}
+}
+
+func TestCMap_FromSeq(t *testing.T) {
+	m := FromSeq(maps.All(map[string]int{"a": 1, "b": 2}))
+	if l := m.Len(); l != 2 {
+		t.Errorf("expected length 2, got %d", l)
+	}
+	if val, ok := m.Get("b"); !ok || val != 2 {
+		t.Errorf("expected 2, got %v, ok=%v", val, ok)
+	}
+}
+
+func TestCMap_GetOrSet(t *testing.T) {
+	var m CMap[string, int]
+
+	if actual, loaded := m.GetOrSet("a", 1); loaded || actual != 1 {
+		t.Errorf("GetOrSet() on absent key: expected (1, false), got (%v, %v)", actual, loaded)
+	}
+	if actual, loaded := m.GetOrSet("a", 2); !loaded || actual != 1 {
+		t.Errorf("GetOrSet() on present key: expected (1, true), got (%v, %v)", actual, loaded)
+	}
+
+	if m.SetIfAbsent("a", 3) {
+		t.Errorf("SetIfAbsent() on present key: expected false")
+	}
+	if !m.SetIfAbsent("b", 3) {
+		t.Errorf("SetIfAbsent() on absent key: expected true")
+	}
+	if val, _ := m.Get("a"); val != 1 {
+		t.Errorf("expected 1, got %v", val)
+	}
+}
+
+func TestCMap_SwapAndGetAndDelete(t *testing.T) {
+	var m CMap[string, int]
+
+	if prev, loaded := m.Swap("a", 1); loaded || prev != 0 {
+		t.Errorf("Swap() on absent key: expected (0, false), got (%v, %v)", prev, loaded)
+	}
+	if prev, loaded := m.Swap("a", 2); !loaded || prev != 1 {
+		t.Errorf("Swap() on present key: expected (1, true), got (%v, %v)", prev, loaded)
+	}
+
+	if val, loaded := m.GetAndDelete("a"); !loaded || val != 2 {
+		t.Errorf("GetAndDelete(): expected (2, true), got (%v, %v)", val, loaded)
+	}
+	if m.Contains("a") {
+		t.Errorf("key 'a' should have been deleted")
+	}
+	if val, loaded := m.GetAndDelete("a"); loaded || val != 0 {
+		t.Errorf("GetAndDelete() on absent key: expected (0, false), got (%v, %v)", val, loaded)
+	}
+}
+
+func TestCMap_CompareAndSwap(t *testing.T) {
+	m := New[string, int]()
+	m.Set("a", 1)
+
+	if CompareAndSwap(m, "a", 2, 3) {
+		t.Errorf("CompareAndSwap() with wrong old value: expected false")
+	}
+	if !CompareAndSwap(m, "a", 1, 3) {
+		t.Errorf("CompareAndSwap() with matching old value: expected true")
+	}
+	if CompareAndSwap(m, "b", 0, 1) {
+		t.Errorf("CompareAndSwap() on absent key: expected false")
+	}
+	if val, _ := m.Get("a"); val != 3 {
+		t.Errorf("expected 3, got %v", val)
+	}
+
+	if CompareAndDelete(m, "a", 1) {
+		t.Errorf("CompareAndDelete() with wrong old value: expected false")
+	}
+	if !CompareAndDelete(m, "a", 3) {
+		t.Errorf("CompareAndDelete() with matching old value: expected true")
+	}
+	if m.Contains("a") {
+		t.Errorf("key 'a' should have been deleted")
+	}
+
+	var zero CMap[string, int]
+	if CompareAndSwap(&zero, "a", 0, 1) || CompareAndDelete(&zero, "a", 0) {
+		t.Errorf("compare operations on zero-value map: expected false")
+	}
+}
+
+func TestCMap_Compute(t *testing.T) {
+	var m CMap[string, int]
+
+	increment := func(old int, ok bool) (int, ComputeOp) {
+		return old + 1, ComputeReplace
+	}
+	if val, ok := m.Compute("a", increment); !ok || val != 1 {
+		t.Errorf("Compute() insert: expected (1, true), got (%v, %v)", val, ok)
+	}
+	if val, ok := m.Compute("a", increment); !ok || val != 2 {
+		t.Errorf("Compute() replace: expected (2, true), got (%v, %v)", val, ok)
+	}
+
+	keep := func(old int, ok bool) (int, ComputeOp) {
+		return 100, ComputeKeep
+	}
+	if val, ok := m.Compute("a", keep); !ok || val != 2 {
+		t.Errorf("Compute() keep: expected (2, true), got (%v, %v)", val, ok)
+	}
+	if val, ok := m.Compute("b", keep); ok || val != 0 {
+		t.Errorf("Compute() keep on absent key: expected (0, false), got (%v, %v)", val, ok)
+	}
+	if m.Contains("b") {
+		t.Errorf("Compute() keep should not insert an absent key")
+	}
+
+	del := func(old int, ok bool) (int, ComputeOp) {
+		return 0, ComputeDelete
+	}
+	if val, ok := m.Compute("a", del); ok || val != 0 {
+		t.Errorf("Compute() delete: expected (0, false), got (%v, %v)", val, ok)
+	}
+	if m.Contains("a") {
+		t.Errorf("key 'a' should have been deleted")
+	}
+}
+
+func TestCMap_ConcurrentCompute(t *testing.T) {
+	m := New[string, int]()
+	wg := sync.WaitGroup{}
+	const goroutines = 50
+	const increments = 100
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				m.Compute("counter", func(old int, ok bool) (int, ComputeOp) {
+					return old + 1, ComputeReplace
+				})
+				m.GetOrSet("once", i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if val, _ := m.Get("counter"); val != goroutines*increments {
+		t.Errorf("expected counter %d, got %d", goroutines*increments, val)
+	}
+}
@@ -0,0 +1,49 @@
+package cmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+)
+
+// MarshalJSON implements json.Marshaler. The map is encoded as a JSON
+// object, as encoding/json encodes a map[K]V, with the entries in key
+// order. This requires K to be a string, an integer type or to implement
+// encoding.TextMarshaler: for any other key type, such as a struct or a
+// float, MarshalJSON returns an error once the map holds an entry.
+// Expired entries are left out.
+//
+// The entries are copied under the read lock, so the encoding is a
+// consistent snapshot even while other goroutines modify the map.
+// Because CMap must not be copied, only a *CMap implements json.Marshaler.
+// A struct holding a CMap by value must be encoded through a pointer, as
+// in json.Marshal(&v): json.Marshal(v) copies the lock, which go vet
+// reports, and encodes the map as {}. Embedding a CMap promotes
+// MarshalJSON to the embedding struct, which then encodes as the map
+// alone; use a named field to keep the other fields.
+func (c *CMap[K, V]) MarshalJSON() ([]byte, error) {
+	keys, values := c.snapshot()
+	m := make(map[K]V, len(keys))
+	for i, k := range keys {
+		m[k] = values[i]
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the map with the members of a JSON object, under the same key type rules
+// as MarshalJSON. The new entries expire after the map's default TTL, if
+// one was configured, and the entries they replace are reported to OnEvict
+// as Deleted or Expired. As is conventional, a JSON null leaves the map
+// unchanged. On error the map is not modified.
+func (c *CMap[K, V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	c.replace(maps.All(m), len(m))
+	return nil
+}
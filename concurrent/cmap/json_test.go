@@ -0,0 +1,134 @@
+package cmap
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCMap_MarshalJSON(t *testing.T) {
+	c := New[string, int]()
+	c.Set("b", 2)
+	c.Set("a", 1)
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != `{"a":1,"b":2}` {
+		t.Errorf("Marshal(): expected %s, got %s", `{"a":1,"b":2}`, data)
+	}
+
+	// Integer keys and TextMarshaler keys are encoded as strings
+	ints := New[int, bool]()
+	ints.Set(1, true)
+	if data, _ := json.Marshal(ints); string(data) != `{"1":true}` {
+		t.Errorf("Marshal() with int keys: expected %s, got %s", `{"1":true}`, data)
+	}
+
+	// Other key types cannot be encoded as an object
+	type point struct{ X, Y int }
+	points := New[point, int]()
+	points.Set(point{1, 2}, 3)
+	if _, err := json.Marshal(points); err == nil {
+		t.Errorf("Marshal() with struct keys: expected error, got nil")
+	}
+
+	data, _ = json.Marshal(New[string, int]())
+	if string(data) != "{}" {
+		t.Errorf("Marshal() of empty map: expected %s, got %s", "{}", data)
+	}
+}
+
+func TestCMap_MarshalJSONSkipsExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewWithOptions(Options[string, int]{Now: clock.Now})
+	c.Set("keep", 1)
+	c.SetWithTTL("drop", 2, time.Second)
+	clock.Advance(2 * time.Second)
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != `{"keep":1}` {
+		t.Errorf("Marshal(): expected %s, got %s", `{"keep":1}`, data)
+	}
+}
+
+func TestCMap_UnmarshalJSON(t *testing.T) {
+	var log evictionLog
+	c := NewWithOptions(Options[string, int]{OnEvict: log.OnEvict})
+	c.Set("old", 9)
+
+	if err := json.Unmarshal([]byte(`{"a":1,"b":2}`), c); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if c.Len() != 2 || c.Contains("old") {
+		t.Errorf("Unmarshal() must replace the contents, got Len() = %d", c.Len())
+	}
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b): expected %d, got %d", 2, v)
+	}
+	if got := log.Events(); !slices.Equal(got, []string{"old:deleted"}) {
+		t.Errorf("OnEvict: expected %v, got %v", []string{"old:deleted"}, got)
+	}
+
+	if err := json.Unmarshal([]byte("null"), c); err != nil {
+		t.Errorf("Unmarshal(null): unexpected error %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"a":"x"}`), c); err == nil {
+		t.Errorf("Unmarshal() of invalid input: expected error, got nil")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Map must be unchanged, got Len() = %d", c.Len())
+	}
+}
+
+func TestCMap_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string            `json:"name"`
+		Jobs CMap[string, int] `json:"jobs"`
+	}
+	var c config
+	c.Name = "app"
+	c.Jobs.Set("a", 1)
+
+	// A field held by value is encoded through a pointer to its struct
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","jobs":{"a":1}}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// Map values are not addressable, so only the default encoding applies
+	data, _ = json.Marshal(map[string]config{"x": {Name: "app"}})
+	if want := `{"x":{"name":"app","jobs":{}}}`; string(data) != want {
+		t.Errorf("Marshal() of a non-addressable field: expected %s, got %s", want, data)
+	}
+
+	// An embedded CMap encodes as the whole struct
+	type embedded struct {
+		CMap[string, int]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.Set("a", 1)
+	data, _ = json.Marshal(&e)
+	if string(data) != `{"a":1}` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `{"a":1}`, data)
+	}
+}
+
+func TestCMap_MarshalJSONUnsupportedKey(t *testing.T) {
+	type point struct{ X, Y int }
+	c := New[point, int]()
+	c.Set(point{1, 2}, 3)
+	if _, err := json.Marshal(c); err == nil {
+		t.Errorf("Marshal() with struct keys: expected error, got nil")
+	}
+}
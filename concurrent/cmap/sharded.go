@@ -0,0 +1,225 @@
+package cmap
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"sync"
+)
+
+// defaultShardCount is the number of shards used by the zero value of
+// Sharded and by NewSharded when a non-positive shard count is given.
+const defaultShardCount = 32
+
+// Sharded is a generic, thread-safe key-value store that spreads its keys
+// across a fixed number of independently locked shards. Keys are assigned
+// to shards by hashing them with hash/maphash, so goroutines working on
+// keys in different shards do not contend for the same lock.
+// The zero value of Sharded[K,V] is ready for use without initialization
+// and uses a default number of shards.
+//
+// Use NewSharded() or NewShardedWithCapacity() to choose the number of
+// shards or to set an initial capacity. All operations are safe for
+// concurrent use by multiple goroutines.
+//
+// Sharded trades some single-goroutine speed and memory for throughput
+// under contention. Prefer CMap when few goroutines access the map at once.
+type Sharded[K comparable, V any] struct {
+	_      noCopy // prevents copying after first use
+	once   sync.Once
+	seed   maphash.Seed
+	shards []shard[K, V]
+	mask   uint64
+}
+
+// shard is one independently locked partition of a Sharded map.
+type shard[K comparable, V any] struct {
+	mu              sync.RWMutex
+	items           map[K]V
+	initialCapacity int
+	_               [64]byte // keep neighbouring shards on separate cache lines
+}
+
+// NewSharded returns an empty Sharded map with the given number of shards,
+// rounded up to a power of two. A non-positive count selects the default.
+func NewSharded[K comparable, V any](shards int) *Sharded[K, V] {
+	return NewShardedWithCapacity[K, V](shards, 0)
+}
+
+// NewShardedWithCapacity returns an empty Sharded map with the given number
+// of shards and a capacity hint for the whole map, which is divided evenly
+// between the shards.
+func NewShardedWithCapacity[K comparable, V any](shards, capacity int) *Sharded[K, V] {
+	m := &Sharded[K, V]{}
+	m.once.Do(func() { m.init(shards, capacity) })
+	return m
+}
+
+// init allocates the shards. The shard count is rounded up to a power of
+// two so that a key's shard can be selected with a mask.
+func (m *Sharded[K, V]) init(shards, capacity int) {
+	if shards <= 0 {
+		shards = defaultShardCount
+	}
+	n := 1 << bits.Len(uint(shards-1))
+	m.seed = maphash.MakeSeed()
+	m.shards = make([]shard[K, V], n)
+	m.mask = uint64(n - 1)
+	for i := range m.shards {
+		m.shards[i].initialCapacity = capacity / n
+		m.shards[i].items = make(map[K]V, capacity/n)
+	}
+}
+
+// shardFor returns the shard that holds key, initializing the map on
+// first use if it is a zero value.
+func (m *Sharded[K, V]) shardFor(key K) *shard[K, V] {
+	m.once.Do(func() { m.init(defaultShardCount, 0) })
+	return &m.shards[maphash.Comparable(m.seed, key)&m.mask]
+}
+
+// allShards returns every shard, initializing the map on first use
+// if it is a zero value.
+func (m *Sharded[K, V]) allShards() []shard[K, V] {
+	m.once.Do(func() { m.init(defaultShardCount, 0) })
+	return m.shards
+}
+
+// Shards returns the number of shards the map is split into.
+func (m *Sharded[K, V]) Shards() int {
+	return len(m.allShards())
+}
+
+// Set associates value with key.
+// If key already exists, its value is replaced.
+func (m *Sharded[K, V]) Set(key K, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = value
+}
+
+// Get returns the value for key and reports whether it was present.
+// Returns the zero value of V if the key does not exist.
+func (m *Sharded[K, V]) Get(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	val, ok := s.items[key]
+	return val, ok
+}
+
+// Delete removes key and its value, if present.
+// It does nothing if the key is not in the map.
+func (m *Sharded[K, V]) Delete(key K) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+}
+
+// Contains reports whether key exists in the map.
+func (m *Sharded[K, V]) Contains(key K) bool {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.items[key]
+	return ok
+}
+
+// Len returns the number of entries in the map.
+// Shards are counted one after another, so when other goroutines modify
+// the map concurrently the result may not match any single point in time.
+func (m *Sharded[K, V]) Len() int {
+	n := 0
+	shards := m.allShards()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.RLock()
+		n += len(s.items)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Keys returns a snapshot of all keys in the map.
+// The returned slice does not reflect later modifications. Shards are
+// visited one after another, so the snapshot is consistent per shard only.
+func (m *Sharded[K, V]) Keys() []K {
+	keys, _ := m.snapshot()
+	return keys
+}
+
+// Reset removes all entries while keeping the current allocation
+// of every shard.
+func (m *Sharded[K, V]) Reset() {
+	shards := m.allShards()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.Lock()
+		clear(s.items)
+		s.mu.Unlock()
+	}
+}
+
+// Clear removes all entries and allocates new underlying maps.
+// Unlike Reset, Clear releases the old allocations to the runtime.
+func (m *Sharded[K, V]) Clear() {
+	shards := m.allShards()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.Lock()
+		s.items = make(map[K]V, s.initialCapacity)
+		s.mu.Unlock()
+	}
+}
+
+// All returns an iterator over the key-value pairs of the map.
+// The iteration order is not specified.
+//
+// Like CMap.All, the iterator runs over a snapshot and does not hold
+// any lock while the loop body runs. The snapshot is consistent per shard.
+func (m *Sharded[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := m.snapshot()
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map.
+// Like All, it runs over a snapshot and does not hold any lock
+// while the loop body runs.
+func (m *Sharded[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		_, values := m.snapshot()
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns copies of the keys and values of the map,
+// where values[i] is the value associated with keys[i].
+// Each shard is read-locked in turn while it is copied.
+func (m *Sharded[K, V]) snapshot() (keys []K, values []V) {
+	n := m.Len()
+	keys = make([]K, 0, n)
+	values = make([]V, 0, n)
+	shards := m.allShards()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.RLock()
+		for k, v := range s.items {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.mu.RUnlock()
+	}
+	return keys, values
+}
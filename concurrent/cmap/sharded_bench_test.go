@@ -0,0 +1,205 @@
+package cmap
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// procCounts are the GOMAXPROCS values the contention benchmarks run at.
+var procCounts = []int{1, 2, 4, 8, 16, 32, 64}
+
+// benchmarkProcs runs fn as a sub-benchmark for every value in procCounts,
+// restoring GOMAXPROCS afterwards. fn is expected to call b.RunParallel,
+// which starts one goroutine per P.
+func benchmarkProcs(b *testing.B, fn func(b *testing.B)) {
+	for _, procs := range procCounts {
+		b.Run("procs="+strconv.Itoa(procs), func(b *testing.B) {
+			prev := runtime.GOMAXPROCS(procs)
+			defer runtime.GOMAXPROCS(prev)
+			b.ReportAllocs()
+			b.Cleanup(func() { runtime.GC() })
+			fn(b)
+		})
+	}
+}
+
+// --------------------
+// Sharded Benchmarks
+// --------------------
+
+func BenchmarkSharded_Set(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	m := NewSharded[string, int](0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+}
+
+func BenchmarkSharded_Get(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	m := NewSharded[string, int](0)
+	for i := 0; i < b.N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = m.Get(strconv.Itoa(i))
+	}
+}
+
+func BenchmarkSharded_ConcurrentSet(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := NewSharded[int, int](0)
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				m.Set(i%100000, i)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkSharded_ConcurrentGet(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := NewSharded[int, int](0)
+		const N = 100000
+		for i := 0; i < N; i++ {
+			m.Set(i, i)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_, _ = m.Get(i % N)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkSharded_ConcurrentMixed(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := NewSharded[int, int](0)
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%4 == 0 {
+					m.Set(i%100000, i)
+				} else {
+					_, _ = m.Get(i % 100000)
+				}
+				i++
+			}
+		})
+	})
+}
+
+// --------------------
+// CMap Benchmarks at varying GOMAXPROCS
+// --------------------
+
+func BenchmarkCMap_ConcurrentSetProcs(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := New[int, int]()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				m.Set(i%100000, i)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkCMap_ConcurrentGetProcs(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := New[int, int]()
+		const N = 100000
+		for i := 0; i < N; i++ {
+			m.Set(i, i)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_, _ = m.Get(i % N)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkCMap_ConcurrentMixedProcs(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		m := New[int, int]()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%4 == 0 {
+					m.Set(i%100000, i)
+				} else {
+					_, _ = m.Get(i % 100000)
+				}
+				i++
+			}
+		})
+	})
+}
+
+// --------------------
+// sync.Map Benchmarks
+// --------------------
+
+func BenchmarkSyncMap_ConcurrentSet(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		var m sync.Map
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				m.Store(i%100000, i)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkSyncMap_ConcurrentGet(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		var m sync.Map
+		const N = 100000
+		for i := 0; i < N; i++ {
+			m.Store(i, i)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_, _ = m.Load(i % N)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkSyncMap_ConcurrentMixed(b *testing.B) {
+	benchmarkProcs(b, func(b *testing.B) {
+		var m sync.Map
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%4 == 0 {
+					m.Store(i%100000, i)
+				} else {
+					_, _ = m.Load(i % 100000)
+				}
+				i++
+			}
+		})
+	})
+}
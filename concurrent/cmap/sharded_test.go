@@ -0,0 +1,158 @@
+package cmap
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSharded_BasicOperations(t *testing.T) {
+	var m Sharded[string, int]
+
+	m.Set("one", 1)
+	m.Set("two", 2)
+
+	if val, ok := m.Get("one"); !ok || val != 1 {
+		t.Errorf("expected 1, got %v, ok=%v", val, ok)
+	}
+	if val, ok := m.Get("two"); !ok || val != 2 {
+		t.Errorf("expected 2, got %v, ok=%v", val, ok)
+	}
+
+	if !m.Contains("one") || !m.Contains("two") {
+		t.Errorf("expected keys to exist")
+	}
+	if m.Contains("three") {
+		t.Errorf("expected key 'three' to not exist")
+	}
+
+	if l := m.Len(); l != 2 {
+		t.Errorf("expected length 2, got %d", l)
+	}
+
+	m.Delete("one")
+	if m.Contains("one") {
+		t.Errorf("key 'one' should have been deleted")
+	}
+
+	keys := m.Keys()
+	if len(keys) != 1 || keys[0] != "two" {
+		t.Errorf("expected keys ['two'], got %v", keys)
+	}
+}
+
+func TestSharded_Shards(t *testing.T) {
+	var zero Sharded[int, int]
+	if n := zero.Shards(); n != defaultShardCount {
+		t.Errorf("expected %d shards for zero value, got %d", defaultShardCount, n)
+	}
+
+	tests := []struct {
+		shards   int
+		expected int
+	}{
+		{0, defaultShardCount},
+		{-1, defaultShardCount},
+		{1, 1},
+		{5, 8},
+		{64, 64},
+	}
+	for _, tt := range tests {
+		if n := NewSharded[int, int](tt.shards).Shards(); n != tt.expected {
+			t.Errorf("NewSharded(%d).Shards() = %d, want %d", tt.shards, n, tt.expected)
+		}
+	}
+}
+
+func TestSharded_ResetAndClear(t *testing.T) {
+	m := NewShardedWithCapacity[int, int](4, 100)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+
+	m.Reset()
+	if m.Len() != 0 {
+		t.Errorf("expected length 0 after Reset, got %d", m.Len())
+	}
+	m.Set(1, 1)
+	if val, ok := m.Get(1); !ok || val != 1 {
+		t.Errorf("expected key 1 after Reset, got %v, ok=%v", val, ok)
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("expected length 0 after Clear, got %d", m.Len())
+	}
+	m.Set(2, 2)
+	if val, ok := m.Get(2); !ok || val != 2 {
+		t.Errorf("expected key 2 after Clear, got %v, ok=%v", val, ok)
+	}
+}
+
+func TestSharded_All(t *testing.T) {
+	m := NewSharded[int, int](4)
+	want := make(map[int]int)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i*10)
+		want[i] = i * 10
+	}
+
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Errorf("All(): expected %v, got %v", want, got)
+	}
+	if got := slices.Sorted(slices.Values(m.Keys())); len(got) != 100 || got[0] != 0 || got[99] != 99 {
+		t.Errorf("Keys(): expected 100 keys from 0 to 99, got %v", got)
+	}
+
+	sum := 0
+	for v := range m.Values() {
+		m.Delete(v / 10) // modifying the map during iteration must not deadlock
+		sum += v
+	}
+	if sum != 49500 {
+		t.Errorf("Values(): expected sum %d, got %d", 49500, sum)
+	}
+	if m.Len() != 0 {
+		t.Errorf("expected length 0 after deleting during iteration, got %d", m.Len())
+	}
+}
+
+func TestSharded_ConcurrentAccess(t *testing.T) {
+	m := NewSharded[string, int](8)
+	wg := sync.WaitGroup{}
+	const n = 1000
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Set(strconv.Itoa(i), i)
+			_, _ = m.Get(strconv.Itoa(i))
+			_ = m.Len()
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != n {
+		t.Errorf("expected length %d after concurrent writes, got %d", n, m.Len())
+	}
+}
+
+func TestSharded_ZeroValueConcurrentInit(t *testing.T) {
+	var m Sharded[int, int]
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Set(i, i)
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != 100 {
+		t.Errorf("expected length %d, got %d", 100, m.Len())
+	}
+}
@@ -0,0 +1,263 @@
+package cmap
+
+import (
+	"time"
+)
+
+// Options configures a CMap created with NewWithOptions.
+// The zero value of Options gives the same map as New().
+type Options[K comparable, V any] struct {
+	// Capacity is a hint for the number of key-value pairs, as in NewWithCapacity.
+	Capacity int
+
+	// DefaultTTL is the time to live of entries stored without an explicit
+	// TTL, e.g. by Set or GetOrSet. Zero or negative means they never expire.
+	DefaultTTL time.Duration
+
+	// SweepInterval, when positive, starts a background goroutine that
+	// removes expired entries at this interval. Call Close to stop it.
+	// Without a sweeper, expired entries are invisible to readers but keep
+	// their memory until they are overwritten, deleted or DeleteExpired runs.
+	SweepInterval time.Duration
+
+	// OnEvict, when set, is called whenever an entry leaves the map, with
+	// the reason it left. It runs after the map's lock has been released,
+	// so it may call methods of the map.
+	OnEvict func(key K, value V, reason EvictionReason)
+
+	// Now returns the current time used to compute and check expirations.
+	// Defaults to time.Now; tests can supply a fake clock.
+	Now func() time.Time
+}
+
+// EvictionReason tells an OnEvict callback why an entry left the map.
+type EvictionReason int
+
+const (
+	// Expired means the entry's time to live elapsed.
+	Expired EvictionReason = iota + 1
+	// Deleted means the entry was removed by Delete, GetAndDelete,
+	// CompareAndDelete, Compute, Reset, Clear, UnmarshalJSON or UnmarshalBinary.
+	Deleted
+	// Replaced means another value was stored for the same key.
+	Replaced
+)
+
+// String returns the name of the reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Replaced:
+		return "replaced"
+	default:
+		return "unknown"
+	}
+}
+
+// eviction records an entry that left the map so that OnEvict can be
+// called once the lock is released. A zero reason means nothing left.
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// NewWithOptions returns an empty CMap configured by opts. If
+// opts.SweepInterval is positive, a background sweeper is started
+// and Close must be called to stop it.
+func NewWithOptions[K comparable, V any](opts Options[K, V]) *CMap[K, V] {
+	c := &CMap[K, V]{
+		items:           make(map[K]V, opts.Capacity),
+		initialCapacity: opts.Capacity,
+		defaultTTL:      opts.DefaultTTL,
+		onEvict:         opts.OnEvict,
+		now:             opts.Now,
+	}
+	if opts.SweepInterval > 0 {
+		c.stop = make(chan struct{})
+		go c.sweep(opts.SweepInterval, c.stop)
+	}
+	return c
+}
+
+// SetWithTTL associates value with key for the duration ttl, after which
+// the entry expires and is no longer visible. A zero or negative ttl
+// means the entry never expires, regardless of the map's default TTL.
+func (c *CMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var ev eviction[K, V]
+	defer c.notify(&ev)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ev = c.store(key, value, c.deadline(ttl))
+}
+
+// DeleteExpired removes every expired entry from the map and returns how
+// many were removed. It is what the background sweeper runs; call it
+// directly to reclaim memory on a map without a sweeper.
+func (c *CMap[K, V]) DeleteExpired() int {
+	var evs []eviction[K, V]
+	defer c.notifyAll(&evs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.expires) == 0 {
+		return 0
+	}
+	now := c.clock().UnixNano()
+	removed := 0
+	for k, deadline := range c.expires {
+		if now < deadline {
+			continue
+		}
+		if c.onEvict != nil {
+			evs = append(evs, eviction[K, V]{key: k, value: c.items[k], reason: Expired})
+		}
+		delete(c.items, k)
+		delete(c.expires, k)
+		removed++
+	}
+	return removed
+}
+
+// Close stops the background sweeper, if any. The map remains usable
+// afterwards. Calling Close more than once has no further effect.
+func (c *CMap[K, V]) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+}
+
+// sweep calls DeleteExpired every interval until stop is closed.
+func (c *CMap[K, V]) sweep(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// clock returns the current time from the injected clock, if any.
+func (c *CMap[K, V]) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// deadline returns the expiration time, in Unix nanoseconds, of an entry
+// stored now with the given ttl, or 0 if it never expires.
+func (c *CMap[K, V]) deadline(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return c.clock().Add(ttl).UnixNano()
+}
+
+// isExpired reports whether key has an expiration time that has passed.
+// The caller must hold c.mu.
+func (c *CMap[K, V]) isExpired(key K) bool {
+	if len(c.expires) == 0 {
+		return false
+	}
+	deadline, ok := c.expires[key]
+	return ok && c.clock().UnixNano() >= deadline
+}
+
+// lookup returns the value for key, treating expired entries as absent.
+// The caller must hold c.mu.
+func (c *CMap[K, V]) lookup(key K) (V, bool) {
+	val, ok := c.items[key]
+	if ok && c.isExpired(key) {
+		var zero V
+		return zero, false
+	}
+	return val, ok
+}
+
+// store associates value with key, expiring at deadline unless it is 0,
+// and returns the eviction of the entry it overwrote, if any.
+// The caller must hold c.mu for writing.
+func (c *CMap[K, V]) store(key K, value V, deadline int64) eviction[K, V] {
+	if c.items == nil {
+		c.items = make(map[K]V, c.initialCapacity)
+	}
+	var ev eviction[K, V]
+	if old, ok := c.items[key]; ok {
+		ev = eviction[K, V]{key: key, value: old, reason: Replaced}
+		if c.isExpired(key) {
+			ev.reason = Expired
+		}
+	}
+	c.items[key] = value
+	if deadline != 0 {
+		if c.expires == nil {
+			c.expires = make(map[K]int64)
+		}
+		c.expires[key] = deadline
+	} else if len(c.expires) > 0 {
+		delete(c.expires, key)
+	}
+	return ev
+}
+
+// remove deletes key and returns its eviction, if it was present.
+// The caller must hold c.mu for writing.
+func (c *CMap[K, V]) remove(key K) eviction[K, V] {
+	old, ok := c.items[key]
+	if !ok {
+		return eviction[K, V]{}
+	}
+	ev := eviction[K, V]{key: key, value: old, reason: Deleted}
+	if c.isExpired(key) {
+		ev.reason = Expired
+	}
+	delete(c.items, key)
+	if len(c.expires) > 0 {
+		delete(c.expires, key)
+	}
+	return ev
+}
+
+// removeAll collects an eviction for every entry before the map is
+// emptied by Reset or Clear. The caller must hold c.mu for writing.
+func (c *CMap[K, V]) removeAll() []eviction[K, V] {
+	if c.onEvict == nil || len(c.items) == 0 {
+		return nil
+	}
+	now := c.clock().UnixNano()
+	evs := make([]eviction[K, V], 0, len(c.items))
+	for k, v := range c.items {
+		reason := Deleted
+		if deadline, ok := c.expires[k]; ok && now >= deadline {
+			reason = Expired
+		}
+		evs = append(evs, eviction[K, V]{key: k, value: v, reason: reason})
+	}
+	return evs
+}
+
+// notify reports ev to the OnEvict callback, if both are present.
+// It must be called without holding c.mu.
+func (c *CMap[K, V]) notify(ev *eviction[K, V]) {
+	if ev.reason != 0 && c.onEvict != nil {
+		c.onEvict(ev.key, ev.value, ev.reason)
+	}
+}
+
+// notifyAll reports every eviction in evs to the OnEvict callback.
+// It must be called without holding c.mu.
+func (c *CMap[K, V]) notifyAll(evs *[]eviction[K, V]) {
+	for i := range *evs {
+		c.notify(&(*evs)[i])
+	}
+}
@@ -0,0 +1,204 @@
+package cmap
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for deterministic TTL tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// evictionLog records OnEvict calls.
+type evictionLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *evictionLog) OnEvict(key string, value int, reason EvictionReason) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, key+":"+reason.String())
+}
+
+func (l *evictionLog) Events() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.events)
+}
+
+func TestCMap_SetWithTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	m := NewWithOptions(Options[string, int]{Now: clock.Now})
+
+	m.SetWithTTL("short", 1, time.Second)
+	m.SetWithTTL("long", 2, time.Minute)
+	m.Set("forever", 3)
+
+	if l := m.Len(); l != 3 {
+		t.Errorf("expected length 3, got %d", l)
+	}
+
+	clock.Advance(time.Second)
+
+	if _, ok := m.Get("short"); ok {
+		t.Errorf("expected key 'short' to have expired")
+	}
+	if m.Contains("short") {
+		t.Errorf("Contains(): expected expired key to be invisible")
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("expected length 2 after expiration, got %d", l)
+	}
+	if keys := slices.Sorted(slices.Values(m.Keys())); !slices.Equal(keys, []string{"forever", "long"}) {
+		t.Errorf("Keys(): expected [forever long], got %v", keys)
+	}
+	for k := range m.All() {
+		if k == "short" {
+			t.Errorf("All(): expected expired key to be invisible")
+		}
+	}
+
+	clock.Advance(time.Hour)
+	if val, ok := m.Get("forever"); !ok || val != 3 {
+		t.Errorf("expected entry without TTL to stay, got %v, ok=%v", val, ok)
+	}
+	if l := m.Len(); l != 1 {
+		t.Errorf("expected length 1, got %d", l)
+	}
+}
+
+func TestCMap_DefaultTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	m := NewWithOptions(Options[string, int]{DefaultTTL: time.Minute, Now: clock.Now})
+
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 0) // explicit zero TTL never expires
+	m.GetOrSet("c", 3)
+
+	clock.Advance(time.Minute)
+
+	if m.Contains("a") || m.Contains("c") {
+		t.Errorf("expected entries stored with the default TTL to have expired")
+	}
+	if !m.Contains("b") {
+		t.Errorf("expected entry stored without TTL to stay")
+	}
+
+	// An expired key behaves as absent for read-modify-write operations
+	if actual, loaded := m.GetOrSet("a", 10); loaded || actual != 10 {
+		t.Errorf("GetOrSet() on expired key: expected (10, false), got (%v, %v)", actual, loaded)
+	}
+	if _, loaded := m.GetAndDelete("c"); loaded {
+		t.Errorf("GetAndDelete() on expired key: expected loaded=false")
+	}
+}
+
+func TestCMap_DeleteExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	log := &evictionLog{}
+	m := NewWithOptions(Options[string, int]{Now: clock.Now, OnEvict: log.OnEvict})
+
+	m.SetWithTTL("a", 1, time.Second)
+	m.SetWithTTL("b", 2, time.Second)
+	m.SetWithTTL("c", 3, time.Minute)
+
+	clock.Advance(time.Second)
+	if n := m.DeleteExpired(); n != 2 {
+		t.Errorf("DeleteExpired(): expected 2, got %d", n)
+	}
+	if n := m.DeleteExpired(); n != 0 {
+		t.Errorf("DeleteExpired() again: expected 0, got %d", n)
+	}
+	if len(m.items) != 1 || len(m.expires) != 1 {
+		t.Errorf("expected expired entries to be removed from the underlying maps")
+	}
+	if events := slices.Sorted(slices.Values(log.Events())); !slices.Equal(events, []string{"a:expired", "b:expired"}) {
+		t.Errorf("OnEvict: expected [a:expired b:expired], got %v", events)
+	}
+}
+
+func TestCMap_OnEvict(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	log := &evictionLog{}
+	var m *CMap[string, int]
+	m = NewWithOptions(Options[string, int]{
+		Now: clock.Now,
+		OnEvict: func(key string, value int, reason EvictionReason) {
+			log.OnEvict(key, value, reason)
+			_ = m.Len() // the callback runs without the lock held
+		},
+	})
+
+	m.Set("a", 1)
+	m.Set("a", 2)
+	m.Delete("a")
+	m.Delete("a") // absent key: no callback
+
+	m.SetWithTTL("b", 1, time.Second)
+	clock.Advance(time.Second)
+	m.Set("b", 2) // overwriting an expired entry reports it as expired
+
+	m.Swap("b", 3)
+	m.Compute("b", func(old int, ok bool) (int, ComputeOp) { return 0, ComputeDelete })
+
+	m.Set("c", 1)
+	m.Reset()
+
+	want := []string{"a:replaced", "a:deleted", "b:expired", "b:replaced", "b:deleted", "c:deleted"}
+	if events := log.Events(); !slices.Equal(events, want) {
+		t.Errorf("OnEvict: expected %v, got %v", want, events)
+	}
+}
+
+func TestCMap_Sweeper(t *testing.T) {
+	evicted := make(chan string, 1)
+	m := NewWithOptions(Options[string, int]{
+		SweepInterval: time.Millisecond,
+		OnEvict: func(key string, value int, reason EvictionReason) {
+			if reason == Expired {
+				evicted <- key
+			}
+		},
+	})
+	defer m.Close()
+
+	m.SetWithTTL("a", 1, time.Millisecond)
+
+	select {
+	case key := <-evicted:
+		if key != "a" {
+			t.Errorf("expected key 'a' to be swept, got %q", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("sweeper did not remove the expired entry")
+	}
+
+	m.Close()
+	m.Close() // closing twice is a no-op
+}
+
+func TestCMap_ZeroValueTTL(t *testing.T) {
+	var m CMap[string, int]
+	m.SetWithTTL("a", 1, time.Hour)
+	if val, ok := m.Get("a"); !ok || val != 1 {
+		t.Errorf("expected 1, got %v, ok=%v", val, ok)
+	}
+	m.Close() // no sweeper: no-op
+}
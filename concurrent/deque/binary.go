@@ -0,0 +1,37 @@
+package deque
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The items are written
+// from front to back in a compact versioned format, from a snapshot
+// taken under the read lock.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// Because Deque must not be copied, only a *Deque implements
+// encoding.BinaryMarshaler and gob.GobEncoder.
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, d.snapshot())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the deque with data encoded by MarshalBinary. On error the
+// deque is not modified.
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	d.replace(items)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (d *Deque[T]) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (d *Deque[T]) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
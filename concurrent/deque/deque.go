@@ -0,0 +1,324 @@
+package deque
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// Deque is a generic, thread-safe double-ended queue implementation
+// backed by a dynamically resizing ring buffer. Items can be pushed and
+// popped at both ends in amortized O(1) time and accessed by index in O(1).
+// The zero value of Deque[T] is ready to use without initialization.
+//
+// Use New() or NewWithCapacity() if you prefer an explicit constructor
+// or want to set an initial capacity.
+// All operations on Deque are safe for concurrent use by multiple goroutines.
+// If you do not need thread-safety, use the collections/deque package instead for better performance.
+type Deque[T any] struct {
+	_               noCopy // prevent accidental copy after first use
+	items           []T    // ring buffer; len(items) is the capacity
+	head            int    // index of the front element
+	count           int    // number of elements in the deque
+	initialCapacity int
+	mu              sync.RWMutex
+}
+
+// shrinkCapacityThreshold defines the minimum buffer capacity before
+// shrink operations are considered. Avoids aggressive shrinking for
+// small deques that would just grow again.
+// shrinkCapacityThreshold is just one parameter when deciding to shrink
+// the underlying array
+const shrinkCapacityThreshold = 16
+
+// New creates an empty deque of type T with no pre-allocated capacity.
+// Use this when you don't know in advance how many elements you will push.
+// This is equivalent to creating a deque as `var d deque.Deque[int]`
+func New[T any]() *Deque[T] {
+	return &Deque[T]{
+		items:           []T{},
+		initialCapacity: 0,
+	}
+}
+
+// NewWithCapacity creates an empty deque of type T with a pre-allocated
+// capacity. This avoids repeated allocations if you know roughly how
+// many elements you’ll push.
+func NewWithCapacity[T any](capacity int) *Deque[T] {
+	return &Deque[T]{
+		items:           make([]T, capacity),
+		initialCapacity: capacity,
+	}
+}
+
+// FromSeq creates a deque holding the values of seq in the order they
+// are produced, so the first value yielded is the front of the deque.
+func FromSeq[T any](seq iter.Seq[T]) *Deque[T] {
+	d := New[T]()
+	for v := range seq {
+		d.PushBack(v)
+	}
+	return d
+}
+
+// PushBack adds a single item to the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.count == len(d.items) {
+		d.grow()
+	}
+	d.items[d.index(d.count)] = item
+	d.count++
+}
+
+// PushFront adds a single item to the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.count == len(d.items) {
+		d.grow()
+	}
+	d.head--
+	if d.head < 0 {
+		d.head += len(d.items)
+	}
+	d.items[d.head] = item
+	d.count++
+}
+
+// PopFront removes and returns the element at the front of the deque.
+// The boolean return is false if the deque is empty.
+// The deque may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+	item := d.items[d.head]
+	d.items[d.head] = zero // release the reference for the GC
+	d.head = d.index(1)
+	d.count--
+	d.shrinkIfNeeded()
+	return item, true
+}
+
+// PopBack removes and returns the element at the back of the deque.
+// The boolean return is false if the deque is empty.
+// The deque may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+	tail := d.index(d.count - 1)
+	item := d.items[tail]
+	d.items[tail] = zero // release the reference for the GC
+	d.count--
+	d.shrinkIfNeeded()
+	return item, true
+}
+
+// PeekFront returns the front of the deque without removing it.
+// The boolean return is false if the deque is empty.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.head], true
+}
+
+// PeekBack returns the back of the deque without removing it.
+// The boolean return is false if the deque is empty.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.index(d.count-1)], true
+}
+
+// At returns the element at position i, counting from the front of the
+// deque, in O(1) time. The boolean return is false if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if i < 0 || i >= d.count {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.index(i)], true
+}
+
+// Len returns the current number of items in the deque.
+func (d *Deque[T]) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.count
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying buffer. This is faster than Clear()
+// when you expect to reuse the same deque size.
+func (d *Deque[T]) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	clear(d.items)
+	d.head = 0
+	d.count = 0
+}
+
+// Clear removes all items and reallocates a buffer with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (d *Deque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = make([]T, d.initialCapacity)
+	d.head = 0
+	d.count = 0
+}
+
+// All returns an iterator over the items of the deque from front to back,
+// without removing them.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the deque; such changes are not
+// seen by the ongoing iteration.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the deque from back to
+// front, without removing them.
+//
+// Like All, it runs over a snapshot and does not hold the lock while
+// the loop body runs.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(d.snapshot()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the items of the deque from front to back.
+func (d *Deque[T]) snapshot() []T {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	items := make([]T, d.count)
+	n := copy(items, d.items[d.head:min(d.head+d.count, len(d.items))])
+	copy(items[n:], d.items[:d.count-n])
+	return items
+}
+
+// index returns the position in the ring buffer of the element
+// that is offset places behind the front of the deque.
+// The caller must hold d.mu.
+func (d *Deque[T]) index(offset int) int {
+	i := d.head + offset
+	if i >= len(d.items) {
+		i -= len(d.items)
+	}
+	return i
+}
+
+// grow doubles the capacity of the buffer to make room for one more element.
+// The caller must hold d.mu.
+func (d *Deque[T]) grow() {
+	d.resize(max(len(d.items)*2, 1))
+}
+
+// shrinkIfNeeded halves the capacity of the buffer when it is mostly empty.
+//
+// Reduce capacity if:
+//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
+//   - current capacity exceeds 2× the initial capacity (if any),
+//   - and fewer than 12.5% of elements are in use (cap/8).
+//
+// Why 1/8 instead of 1/4?
+//
+//	Using 1/4 is fine for general use, but in tight push/pop workloads
+//	it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
+//	only when the deque is significantly underutilized.
+//
+// Why halve capacity?
+//
+//	Halving avoids repeated reallocations while still reclaiming
+//	unused memory proportionally. It balances memory efficiency and speed.
+//
+// The caller must hold d.mu.
+func (d *Deque[T]) shrinkIfNeeded() {
+	capNow := len(d.items)
+	if capNow > shrinkCapacityThreshold &&
+		(d.initialCapacity == 0 || capNow > d.initialCapacity*2) &&
+		d.count < capNow/8 {
+
+		newCap := capNow / 2
+		if d.initialCapacity > 0 && newCap < d.initialCapacity {
+			newCap = d.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			d.resize(newCap)
+		}
+	}
+}
+
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the front of the deque is at index 0.
+// The caller must hold d.mu.
+func (d *Deque[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if d.count > 0 {
+		n := copy(newItems, d.items[d.head:min(d.head+d.count, len(d.items))])
+		copy(newItems[n:], d.items[:d.count-n])
+	}
+	d.items = newItems
+	d.head = 0
+}
+
+// replace makes items the contents of the deque, the first item becoming
+// the front. The buffer keeps at least the initial capacity.
+func (d *Deque[T]) replace(items []T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(items) < d.initialCapacity {
+		items = append(make([]T, 0, d.initialCapacity), items...)
+	}
+	d.items = items[:cap(items)]
+	d.head = 0
+	d.count = len(items)
+}
+
+// noCopy may be added to structs which must not be copied
+// after the first use.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527
+// for details.
+//
+// Note that it must not be embedded, due to the Lock and Unlock methods.
+type noCopy struct{}
+
+// Lock is a no-op used by -copylocks checker from `go vet`.
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
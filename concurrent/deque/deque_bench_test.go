@@ -0,0 +1,88 @@
+package deque
+
+import (
+	"runtime"
+	"testing"
+)
+
+func BenchmarkDeque_PushBack(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for b.Loop() {
+		d.PushBack(1)
+	}
+}
+
+func BenchmarkDeque_PushFront(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for b.Loop() {
+		d.PushFront(1)
+	}
+}
+
+func BenchmarkDeque_PushBackWithCapacity(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := NewWithCapacity[int](b.N)
+	for b.Loop() {
+		d.PushBack(1)
+	}
+}
+
+func BenchmarkDeque_PopFront(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(1)
+	}
+	for b.Loop() {
+		d.PopFront()
+	}
+}
+
+func BenchmarkDeque_PopBack(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(1)
+	}
+	for b.Loop() {
+		d.PopBack()
+	}
+}
+
+func BenchmarkDeque_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	for b.Loop() {
+		d.PushBack(1)
+		d.PopFront()
+		d.PushFront(1)
+		d.PopBack()
+	}
+}
+
+func BenchmarkDeque_ConcurrentSteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	d := New[int]()
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			d.PushBack(1)
+			d.PopFront()
+		}
+	})
+}
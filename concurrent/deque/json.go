@@ -0,0 +1,37 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The deque is encoded as a JSON
+// array holding its items from front to back. The items are copied under
+// the read lock, so the encoding is a consistent snapshot even while other
+// goroutines modify the deque. An empty deque encodes as [].
+//
+// Because Deque must not be copied, only a *Deque implements json.Marshaler.
+// A struct holding a Deque by value must be encoded through a pointer, as
+// in json.Marshal(&v): json.Marshal(v) copies the lock, which go vet
+// reports, and encodes the deque as {}. Embedding a Deque promotes
+// MarshalJSON to the embedding struct, which then encodes as the deque
+// alone; use a named field to keep the other fields.
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.snapshot())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the deque with the items of a JSON array, the first element becoming the
+// front. As is conventional, a JSON null leaves the deque unchanged.
+// On error the deque is not modified.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	d.replace(items)
+	return nil
+}
@@ -0,0 +1,73 @@
+package deque
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestDeque_JSON(t *testing.T) {
+	d := NewWithCapacity[int](4)
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1) // wraps around the ring buffer
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Marshal(): expected %s, got %s", "[1,2,3]", data)
+	}
+
+	decoded := New[int]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Unmarshal(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	data, _ = json.Marshal(New[int]())
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty deque: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestDeque_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string        `json:"name"`
+		Jobs Deque[string] `json:"jobs"`
+	}
+	var c config
+	c.Name = "app"
+	c.Jobs.PushBack("a")
+
+	// A field held by value is encoded through a pointer to its struct
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","jobs":["a"]}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// Map values are not addressable, so only the default encoding applies
+	data, _ = json.Marshal(map[string]config{"x": {Name: "app"}})
+	if want := `{"x":{"name":"app","jobs":{}}}`; string(data) != want {
+		t.Errorf("Marshal() of a non-addressable field: expected %s, got %s", want, data)
+	}
+
+	// An embedded Deque encodes as the whole struct
+	type embedded struct {
+		Deque[string]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.PushBack("a")
+	data, _ = json.Marshal(&e)
+	if string(data) != `["a"]` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `["a"]`, data)
+	}
+}
@@ -0,0 +1,306 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+)
+
+// Cache is a generic, thread-safe LRU (least-recently-used) cache that holds
+// at most a fixed number of entries. When a new key is set on a full cache,
+// the entry that was used least recently is evicted to make room.
+//
+// The implementation follows the locking model of collections/concurrent/cmap:
+// an underlying map protected by a sync.RWMutex, plus an intrusive doubly
+// linked list that keeps entries in recency order. Get moves the entry to the
+// front of the list and therefore takes the write lock; use Peek for a
+// read-locked lookup that does not affect recency.
+//
+// Use New() or NewWithEvict() to create a cache with a capacity. The zero
+// value of Cache[K,V] is ready to use and has no capacity limit until
+// Resize is called.
+type Cache[K comparable, V any] struct {
+	_        noCopy // prevents copying after first use
+	items    map[K]*entry[K, V]
+	root     entry[K, V] // sentinel: root.next is the most recent entry, root.prev the least recent
+	capacity int         // maximum number of entries; 0 means unbounded
+	onEvict  func(key K, value V)
+	mu       sync.RWMutex
+}
+
+// entry is a node of the recency list.
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+// New returns an empty cache that holds at most capacity entries.
+// A capacity of zero or less means the cache is unbounded.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithEvict[K, V](capacity, nil)
+}
+
+// NewWithEvict returns an empty cache that holds at most capacity entries
+// and calls onEvict for every entry it evicts to stay within capacity.
+// onEvict runs after the cache's lock has been released, so it may call
+// methods of the cache. It is not called for entries removed by Remove,
+// Reset or Clear.
+func NewWithEvict[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	c := &Cache[K, V]{
+		items:    make(map[K]*entry[K, V], max(capacity, 0)),
+		capacity: max(capacity, 0),
+		onEvict:  onEvict,
+	}
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	return c
+}
+
+// Set associates value with key and marks it as the most recently used
+// entry. If key already exists, its value is replaced. If the cache is
+// full, the least recently used entry is evicted.
+func (c *Cache[K, V]) Set(key K, value V) {
+	var evicted *entry[K, V]
+	defer c.notify(&evicted)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lazyInit()
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.moveToFront(e)
+		return
+	}
+
+	var e *entry[K, V]
+	if c.capacity > 0 && len(c.items) >= c.capacity {
+		// Reuse the evicted node for the new entry to avoid an allocation
+		e = c.root.prev
+		c.unlink(e)
+		delete(c.items, e.key)
+		if c.onEvict != nil {
+			evicted = &entry[K, V]{key: e.key, value: e.value}
+		}
+	} else {
+		e = &entry[K, V]{}
+	}
+	e.key = key
+	e.value = value
+	c.pushFront(e)
+	c.items[key] = e
+}
+
+// Get returns the value for key and reports whether it was present.
+// A successful Get marks the entry as the most recently used one.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveToFront(e)
+	return e.value, true
+}
+
+// Peek returns the value for key and reports whether it was present,
+// without changing its recency. Peek only takes the read lock.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Contains reports whether key is in the cache, without changing its recency.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+// Remove deletes key from the cache and reports whether it was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries, or 0 if the cache is unbounded.
+func (c *Cache[K, V]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.capacity
+}
+
+// Resize changes the capacity of the cache, evicting the least recently
+// used entries if it holds more than the new capacity. A capacity of zero
+// or less makes the cache unbounded. Resize returns the number of entries
+// evicted.
+func (c *Cache[K, V]) Resize(capacity int) int {
+	var evicted []entry[K, V]
+	defer c.notifyAll(&evicted)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lazyInit()
+	c.capacity = max(capacity, 0)
+	n := 0
+	for c.capacity > 0 && len(c.items) > c.capacity {
+		e := c.root.prev
+		c.unlink(e)
+		delete(c.items, e.key)
+		if c.onEvict != nil {
+			evicted = append(evicted, entry[K, V]{key: e.key, value: e.value})
+		}
+		n++
+	}
+	return n
+}
+
+// Keys returns a snapshot of all keys in the cache, from the most to the
+// least recently used. The returned slice does not reflect later modifications.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, len(c.items))
+	for e := c.root.next; e != nil && e != &c.root; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// All returns an iterator over the entries of the cache, from the most to
+// the least recently used, without changing their recency.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the cache; such changes are not
+// seen by the ongoing iteration.
+func (c *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.mu.RLock()
+		snapshot := make([]entry[K, V], 0, len(c.items))
+		for e := c.root.next; e != nil && e != &c.root; e = e.next {
+			snapshot = append(snapshot, entry[K, V]{key: e.key, value: e.value})
+		}
+		c.mu.RUnlock()
+
+		for _, e := range snapshot {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Reset removes all entries while keeping the current allocation of the
+// underlying map. The capacity is unchanged.
+func (c *Cache[K, V]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.lazyInit()
+		return
+	}
+	clear(c.items)
+	c.root.next = &c.root
+	c.root.prev = &c.root
+}
+
+// Clear removes all entries and allocates a new underlying map.
+// Unlike Reset, Clear releases the old allocation to the runtime.
+// The capacity is unchanged.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*entry[K, V], c.capacity)
+	c.root.next = &c.root
+	c.root.prev = &c.root
+}
+
+// lazyInit prepares a zero-value cache for use.
+// The caller must hold c.mu for writing.
+func (c *Cache[K, V]) lazyInit() {
+	if c.items == nil {
+		c.items = make(map[K]*entry[K, V], c.capacity)
+	}
+	if c.root.next == nil {
+		c.root.next = &c.root
+		c.root.prev = &c.root
+	}
+}
+
+// pushFront inserts e at the front of the recency list.
+// The caller must hold c.mu for writing.
+func (c *Cache[K, V]) pushFront(e *entry[K, V]) {
+	e.prev = &c.root
+	e.next = c.root.next
+	c.root.next.prev = e
+	c.root.next = e
+}
+
+// unlink removes e from the recency list.
+// The caller must hold c.mu for writing.
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+}
+
+// moveToFront marks e as the most recently used entry.
+// The caller must hold c.mu for writing.
+func (c *Cache[K, V]) moveToFront(e *entry[K, V]) {
+	if c.root.next == e {
+		return
+	}
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+// notify reports an evicted entry to the OnEvict callback, if any.
+// It must be called without holding c.mu.
+func (c *Cache[K, V]) notify(evicted **entry[K, V]) {
+	if *evicted != nil && c.onEvict != nil {
+		c.onEvict((*evicted).key, (*evicted).value)
+	}
+}
+
+// notifyAll reports every evicted entry to the OnEvict callback, if any.
+// It must be called without holding c.mu.
+func (c *Cache[K, V]) notifyAll(evicted *[]entry[K, V]) {
+	for _, e := range *evicted {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// noCopy may be added to structs which must not be copied
+// after the first use.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527
+// for details.
+//
+// Note that it must not be embedded, due to the Lock and Unlock methods.
+type noCopy struct{}
+
+// Lock is a no-op used by -copylocks checker from `go vet`.
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
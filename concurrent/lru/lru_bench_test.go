@@ -0,0 +1,168 @@
+package lru
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/khavishbhundoo/collections/concurrent/cmap"
+)
+
+// --------------------
+// Cache Benchmarks
+// --------------------
+
+func BenchmarkCache_Set(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	c := New[string, int](b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+}
+
+func BenchmarkCache_SetEvict(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	c := New[int, int](1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(i, i)
+	}
+}
+
+func BenchmarkCache_Get(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	c := New[string, int](b.N)
+	for i := 0; i < b.N; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.Get(strconv.Itoa(i))
+	}
+}
+
+func BenchmarkCache_Peek(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	c := New[string, int](b.N)
+	for i := 0; i < b.N; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.Peek(strconv.Itoa(i))
+	}
+}
+
+func BenchmarkCache_ConcurrentSet(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	c := New[string, int](100000)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Set(strconv.Itoa(i), i)
+			i++
+		}
+	})
+}
+
+func BenchmarkCache_ConcurrentGet(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	const N = 100000
+	c := New[string, int](N)
+	for i := 0; i < N; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = c.Get(strconv.Itoa(i % N))
+			i++
+		}
+	})
+}
+
+func BenchmarkCache_ConcurrentPeek(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	const N = 100000
+	c := New[string, int](N)
+	for i := 0; i < N; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = c.Peek(strconv.Itoa(i % N))
+			i++
+		}
+	})
+}
+
+// --------------------
+// CMap Benchmarks
+// --------------------
+
+func BenchmarkCMap_Set(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	m := cmap.NewWithCapacity[string, int](b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+}
+
+func BenchmarkCMap_Get(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	m := cmap.NewWithCapacity[string, int](b.N)
+	for i := 0; i < b.N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = m.Get(strconv.Itoa(i))
+	}
+}
+
+func BenchmarkCMap_ConcurrentSet(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	m := cmap.NewWithCapacity[string, int](100000)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Set(strconv.Itoa(i), i)
+			i++
+		}
+	})
+}
+
+func BenchmarkCMap_ConcurrentGet(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	const N = 100000
+	m := cmap.NewWithCapacity[string, int](N)
+	for i := 0; i < N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = m.Get(strconv.Itoa(i % N))
+			i++
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package lru_test
+
+import (
+	"fmt"
+
+	"github.com/khavishbhundoo/collections/concurrent/lru"
+)
+
+func ExampleCache() {
+	c := lru.NewWithEvict(2, func(key string, value int) {
+		fmt.Println("evicted", key, value)
+	})
+
+	c.Set("Go", 1)
+	c.Set("C#", 2)
+
+	// Get marks "Go" as recently used, so "C#" is evicted next
+	if v, ok := c.Get("Go"); ok {
+		fmt.Println("Go =", v)
+	}
+	c.Set("Rust", 3)
+
+	fmt.Println("Contains C#?", c.Contains("C#"))
+	fmt.Println("Keys:", c.Keys())
+
+	// Shrinking the cache evicts the least recently used entries
+	c.Resize(1)
+	fmt.Println("Len =", c.Len())
+
+	// The zero value of Cache[K,V] is ready to use and unbounded
+	var n lru.Cache[string, int]
+	n.Set("Go", 1)
+	fmt.Println(n.Len())
+
+	// Output:
+	// Go = 1
+	// evicted C# 2
+	// Contains C#? false
+	// Keys: [Rust Go]
+	// evicted Go 1
+	// Len = 1
+	// 1
+}
@@ -0,0 +1,225 @@
+package lru
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCache_BasicOperations(t *testing.T) {
+	c := New[string, int](2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("expected 1, got %v, ok=%v", val, ok)
+	}
+	if val, ok := c.Peek("b"); !ok || val != 2 {
+		t.Errorf("expected 2, got %v, ok=%v", val, ok)
+	}
+	if !c.Contains("a") || c.Contains("z") {
+		t.Errorf("Contains(): unexpected result")
+	}
+	if l := c.Len(); l != 2 {
+		t.Errorf("expected length 2, got %d", l)
+	}
+
+	// "a" was used more recently than "b", so "b" is evicted
+	c.Set("c", 3)
+	if c.Contains("b") {
+		t.Errorf("expected key 'b' to be evicted")
+	}
+	if keys := c.Keys(); !slices.Equal(keys, []string{"c", "a"}) {
+		t.Errorf("Keys(): expected [c a], got %v", keys)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Errorf("Remove(): expected true then false")
+	}
+	if l := c.Len(); l != 1 {
+		t.Errorf("expected length 1 after Remove, got %d", l)
+	}
+}
+
+func TestCache_PeekDoesNotPromote(t *testing.T) {
+	c := New[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Peek("a")
+	c.Set("c", 3)
+	if c.Contains("a") {
+		t.Errorf("expected key 'a' to be evicted after Peek")
+	}
+}
+
+func TestCache_SetExistingPromotes(t *testing.T) {
+	c := New[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 10)
+	c.Set("c", 3)
+
+	if val, ok := c.Get("a"); !ok || val != 10 {
+		t.Errorf("expected 10, got %v, ok=%v", val, ok)
+	}
+	if c.Contains("b") {
+		t.Errorf("expected key 'b' to be evicted")
+	}
+}
+
+func TestCache_OnEvict(t *testing.T) {
+	var evicted []string
+	var c *Cache[string, int]
+	c = NewWithEvict(2, func(key string, value int) {
+		evicted = append(evicted, key+"="+strconv.Itoa(value))
+		_ = c.Len() // the callback runs without the lock held
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Remove("b") // Remove does not call OnEvict
+	c.Set("d", 4)
+	c.Set("e", 5)
+
+	if want := []string{"a=1", "c=3"}; !slices.Equal(evicted, want) {
+		t.Errorf("OnEvict: expected %v, got %v", want, evicted)
+	}
+}
+
+func TestCache_Resize(t *testing.T) {
+	var evicted []string
+	c := NewWithEvict(4, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	for _, k := range []string{"a", "b", "c", "d"} {
+		c.Set(k, 0)
+	}
+
+	if n := c.Resize(2); n != 2 {
+		t.Errorf("Resize(): expected 2 evictions, got %d", n)
+	}
+	if !slices.Equal(evicted, []string{"a", "b"}) {
+		t.Errorf("OnEvict: expected [a b], got %v", evicted)
+	}
+	if c.Cap() != 2 || c.Len() != 2 {
+		t.Errorf("expected cap 2 and length 2, got %d and %d", c.Cap(), c.Len())
+	}
+
+	if n := c.Resize(0); n != 0 {
+		t.Errorf("Resize(0): expected 0 evictions, got %d", n)
+	}
+	for i := 0; i < 100; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	if c.Len() != 102 {
+		t.Errorf("expected unbounded cache to hold 102 entries, got %d", c.Len())
+	}
+}
+
+func TestCache_ZeroValue(t *testing.T) {
+	var c Cache[int, string]
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get() on zero-value cache: expected false")
+	}
+	if c.Remove(1) {
+		t.Errorf("Remove() on zero-value cache: expected false")
+	}
+	if len(c.Keys()) != 0 {
+		t.Errorf("Keys() on zero-value cache: expected none")
+	}
+
+	c.Set(1, "one")
+	if val, ok := c.Get(1); !ok || val != "one" {
+		t.Errorf("expected 'one', got %v, ok=%v", val, ok)
+	}
+
+	c.Resize(1)
+	c.Set(2, "two")
+	if c.Contains(1) || !c.Contains(2) {
+		t.Errorf("expected key 1 to be evicted after Resize")
+	}
+}
+
+func TestCache_ResetAndClear(t *testing.T) {
+	c := New[int, int](10)
+	c.Set(1, 1)
+	c.Set(2, 2)
+
+	c.Reset()
+	if c.Len() != 0 || len(c.Keys()) != 0 {
+		t.Errorf("expected empty cache after Reset")
+	}
+	c.Set(3, 3)
+	if val, ok := c.Get(3); !ok || val != 3 {
+		t.Errorf("expected key 3 after Reset, got %v, ok=%v", val, ok)
+	}
+
+	c.Clear()
+	if c.Len() != 0 || c.Cap() != 10 {
+		t.Errorf("expected empty cache with unchanged capacity after Clear")
+	}
+	c.Set(4, 4)
+	if keys := c.Keys(); !slices.Equal(keys, []int{4}) {
+		t.Errorf("Keys(): expected [4], got %v", keys)
+	}
+
+	var zero Cache[int, int]
+	zero.Reset()
+	zero.Set(1, 1)
+	if zero.Len() != 1 {
+		t.Errorf("expected length 1, got %d", zero.Len())
+	}
+}
+
+func TestCache_All(t *testing.T) {
+	c := New[int, int](3)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Set(3, 30)
+	c.Get(1)
+
+	var keys []int
+	for k, v := range c.All() {
+		if v != k*10 {
+			t.Errorf("All(): expected value %d for key %d, got %d", k*10, k, v)
+		}
+		c.Remove(k) // modifying the cache during iteration must not deadlock
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []int{1, 3, 2}) {
+		t.Errorf("All(): expected [1 3 2], got %v", keys)
+	}
+}
+
+func TestCache_ConcurrentAccess(t *testing.T) {
+	const capacity = 100
+	c := New[int, int](capacity)
+	wg := sync.WaitGroup{}
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(base int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Set(base*1000+i, i)
+				c.Get(base*1000 + i/2)
+				c.Peek(i)
+				if i%100 == 0 {
+					c.Remove(base*1000 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if l := c.Len(); l > capacity {
+		t.Errorf("expected at most %d entries, got %d", capacity, l)
+	}
+	if l := len(c.Keys()); l != c.Len() {
+		t.Errorf("expected recency list and map to agree, got %d and %d", l, c.Len())
+	}
+}
@@ -0,0 +1,390 @@
+package pqueue
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// PQueue is a generic, thread-safe priority queue implementation
+// backed by a binary heap stored in a dynamically resizing slice.
+// Pop always returns the item with the highest priority, as decided
+// by the less function given at construction: if less(a, b) is true,
+// a is popped before b. Items of equal priority are popped in no
+// particular order.
+//
+// Use New() or NewWithCapacity() with a custom less function, or
+// NewOrdered() and NewOrderedMax() for ordered types. Unlike the other
+// collections, the zero value of PQueue[T] is not ready to use: there is
+// no ordering for an arbitrary T to fall back on, so it can be popped and
+// peeked as an empty queue, but Push panics on it.
+// All operations on PQueue are safe for concurrent use by multiple goroutines.
+// If you do not need thread-safety, use the collections/pqueue package instead for better performance.
+type PQueue[T any] struct {
+	_               noCopy // prevent accidental copy after first use
+	items           []T    // binary heap; the children of i are 2i+1 and 2i+2
+	less            func(a, b T) bool
+	initialCapacity int
+	notEmpty        chan struct{} // closed when an item is added; nil if no PopWait is waiting
+	mu              sync.RWMutex
+}
+
+// shrinkCapacityThreshold defines the minimum slice capacity before
+// shrink operations are considered. Avoids aggressive shrinking for
+// small queues that would just grow again.
+// shrinkCapacityThreshold is just one parameter when deciding to shrink
+// the underlying array
+const shrinkCapacityThreshold = 16
+
+// New creates an empty priority queue of type T ordered by less,
+// with no pre-allocated capacity. Items for which less reports true
+// are popped first, so cmp.Less gives a min-queue.
+// It panics if less is nil.
+func New[T any](less func(a, b T) bool) *PQueue[T] {
+	mustLess(less)
+	return &PQueue[T]{
+		items:           []T{},
+		less:            less,
+		initialCapacity: 0,
+	}
+}
+
+// NewWithCapacity creates an empty priority queue of type T ordered by
+// less, with a pre-allocated capacity. This avoids repeated allocations
+// if you know roughly how many elements you’ll push.
+// It panics if less is nil.
+func NewWithCapacity[T any](capacity int, less func(a, b T) bool) *PQueue[T] {
+	mustLess(less)
+	return &PQueue[T]{
+		items:           make([]T, 0, capacity),
+		less:            less,
+		initialCapacity: capacity,
+	}
+}
+
+// NewOrdered creates an empty min-priority queue of an ordered type,
+// which pops the smallest item first.
+func NewOrdered[T cmp.Ordered]() *PQueue[T] {
+	return New(cmp.Less[T])
+}
+
+// NewOrderedMax creates an empty max-priority queue of an ordered type,
+// which pops the largest item first.
+func NewOrderedMax[T cmp.Ordered]() *PQueue[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// FromSeq creates a priority queue ordered by less holding the values
+// of seq. The heap is built in linear time once all values are read.
+// It panics if less is nil, before reading seq.
+func FromSeq[T any](seq iter.Seq[T], less func(a, b T) bool) *PQueue[T] {
+	pq := New(less)
+	pq.items = slices.AppendSeq(pq.items, seq)
+	pq.init()
+	return pq
+}
+
+// PushMany pushes one or more items onto the queue.
+// Equivalent to calling Push repeatedly but more efficient
+// when adding many elements to a small queue.
+func (pq *PQueue[T]) PushMany(item ...T) {
+	pq.mustOrder()
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	n := len(pq.items)
+	pq.items = append(pq.items, item...)
+	// Rebuilding the whole heap is linear, which beats sifting
+	// every new item up once they outnumber the existing ones.
+	if len(item) > n {
+		pq.init()
+	} else {
+		for i := n; i < len(pq.items); i++ {
+			pq.up(i)
+		}
+	}
+	if len(item) > 0 {
+		signal(&pq.notEmpty)
+	}
+}
+
+// Push adds a single item to the queue.
+func (pq *PQueue[T]) Push(item T) {
+	pq.mustOrder()
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	pq.items = append(pq.items, item)
+	pq.up(len(pq.items) - 1)
+	signal(&pq.notEmpty)
+}
+
+// Pop removes and returns the item with the highest priority.
+// The boolean return is false if the queue is empty.
+// The queue may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (pq *PQueue[T]) Pop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.pop()
+}
+
+// PopWait removes and returns the item with the highest priority,
+// blocking until one is available. It returns ctx.Err() if the
+// context is done before an item arrives.
+func (pq *PQueue[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		pq.mu.Lock()
+		if item, ok := pq.pop(); ok {
+			pq.mu.Unlock()
+			return item, nil
+		}
+		wait := waitChan(&pq.notEmpty)
+		pq.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Peek returns the item with the highest priority without removing it.
+// The boolean return is false if the queue is empty.
+func (pq *PQueue[T]) Peek() (T, bool) {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0], true
+}
+
+// Len returns the current number of items in the queue.
+func (pq *PQueue[T]) Len() int {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+	return len(pq.items)
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying slice. This is faster than Clear()
+// when you expect to reuse the same queue size.
+func (pq *PQueue[T]) Reset() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// Clear removes all items and reallocates a slice with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (pq *PQueue[T]) Clear() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	pq.items = make([]T, 0, pq.initialCapacity)
+}
+
+// All returns an iterator over the items of the queue in priority
+// order, the order in which Pop would return them, without removing
+// them. It sorts a copy of the items, so it costs O(n log n) time
+// and O(n) memory.
+//
+// The copy is taken under the read lock when iteration starts, so the
+// lock is not held while the loop body runs. The loop body may therefore
+// modify the queue; such changes are not seen by the ongoing iteration.
+func (pq *PQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range pq.sorted() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue in reverse
+// priority order, lowest priority first, without removing them.
+//
+// Like All, it runs over a sorted snapshot and does not hold the lock
+// while the loop body runs.
+func (pq *PQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(pq.sorted()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// sorted returns a copy of the items in priority order. Only the
+// copy is made under the read lock; sorting happens without it.
+func (pq *PQueue[T]) sorted() []T {
+	pq.mu.RLock()
+	items := slices.Clone(pq.items)
+	pq.mu.RUnlock()
+	if len(items) > 1 {
+		slices.SortFunc(items, pq.compare)
+	}
+	return items
+}
+
+// compare adapts less to the three-way comparison used by slices.SortFunc.
+func (pq *PQueue[T]) compare(a, b T) int {
+	switch {
+	case pq.less(a, b):
+		return -1
+	case pq.less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// mustLess panics if less is nil, so that a queue without an ordering
+// fails where it is created rather than on its first Push.
+func mustLess[T any](less func(a, b T) bool) {
+	if less == nil {
+		panic("pqueue: nil less function")
+	}
+}
+
+// mustOrder panics with a helpful message when items are pushed onto
+// a zero-value queue, which has no less function to order them by.
+// less is set at construction and never changes, so no lock is needed.
+func (pq *PQueue[T]) mustOrder() {
+	if pq.less == nil {
+		panic("pqueue: Push on a PQueue without a less function; use New or NewOrdered")
+	}
+}
+
+// pop removes and returns the item with the highest priority,
+// shrinking the slice when appropriate. The caller must hold pq.mu.
+func (pq *PQueue[T]) pop() (T, bool) {
+	var zero T
+	if len(pq.items) == 0 {
+		return zero, false
+	}
+	last := len(pq.items) - 1
+	item := pq.items[0]
+	pq.items[0] = pq.items[last]
+	pq.items[last] = zero // release the reference for the GC
+	pq.items = pq.items[:last]
+	if last > 0 {
+		pq.down(0)
+	}
+
+	// Reduce capacity if:
+	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
+	//   - current capacity exceeds 2× the initial capacity (if any),
+	//   - and fewer than 12.5% of elements are in use (cap/8).
+	//
+	// Why 1/8 instead of 1/4?
+	//   Using 1/4 is fine for general use, but in tight push/pop workloads
+	//   it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
+	//   only when the queue is significantly underutilized.
+	//
+	// Why halve capacity?
+	//   Halving avoids repeated reallocations while still reclaiming
+	//   unused memory proportionally. It balances memory efficiency and speed.
+	capNow := cap(pq.items)
+	if capNow > shrinkCapacityThreshold &&
+		(pq.initialCapacity == 0 || capNow > pq.initialCapacity*2) &&
+		len(pq.items) < capNow/8 {
+
+		newCap := capNow / 2
+		if pq.initialCapacity > 0 && newCap < pq.initialCapacity {
+			newCap = pq.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			newItems := make([]T, len(pq.items), newCap)
+			copy(newItems, pq.items)
+			pq.items = newItems
+		}
+	}
+
+	return item, true
+}
+
+// init establishes the heap invariant over all items in linear time.
+// The caller must hold pq.mu, unless pq is not yet shared.
+func (pq *PQueue[T]) init() {
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+}
+
+// up moves the item at index i towards the root until its parent
+// does not have a lower priority. The caller must hold pq.mu.
+func (pq *PQueue[T]) up(i int) {
+	item := pq.items[i]
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(item, pq.items[parent]) {
+			break
+		}
+		pq.items[i] = pq.items[parent]
+		i = parent
+	}
+	pq.items[i] = item
+}
+
+// down moves the item at index i towards the leaves until none of its
+// children has a higher priority. The caller must hold pq.mu.
+func (pq *PQueue[T]) down(i int) {
+	n := len(pq.items)
+	item := pq.items[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right], pq.items[child]) {
+			child = right
+		}
+		if !pq.less(pq.items[child], item) {
+			break
+		}
+		pq.items[i] = pq.items[child]
+		i = child
+	}
+	pq.items[i] = item
+}
+
+// waitChan returns the channel *ch that is closed on the next signal,
+// creating it if no goroutine is waiting yet. The caller must hold
+// the lock that guards *ch.
+func waitChan(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// signal wakes up every goroutine waiting on *ch by closing it.
+// It is a no-op when nobody is waiting. The caller must hold the
+// lock that guards *ch.
+func signal(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
+
+// noCopy may be added to structs which must not be copied
+// after the first use.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527
+// for details.
+//
+// Note that it must not be embedded, due to the Lock and Unlock methods.
+type noCopy struct{}
+
+// Lock is a no-op used by -copylocks checker from `go vet`.
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
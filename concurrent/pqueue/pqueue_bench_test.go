@@ -0,0 +1,67 @@
+package pqueue
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"runtime"
+	"testing"
+)
+
+func BenchmarkPQueue_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for b.Loop() {
+		pq.Push(rand.Int())
+	}
+}
+
+func BenchmarkPQueue_PushWithCapacity(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewWithCapacity(b.N, cmp.Less[int])
+	for b.Loop() {
+		pq.Push(rand.Int())
+	}
+}
+
+func BenchmarkPQueue_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for i := 0; i < b.N; i++ {
+		pq.Push(rand.Int())
+	}
+	for b.Loop() {
+		pq.Pop()
+	}
+}
+
+func BenchmarkPQueue_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(rand.Int())
+	}
+	for b.Loop() {
+		pq.Push(rand.Int())
+		pq.Pop()
+	}
+}
+
+func BenchmarkPQueue_ConcurrentSteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(rand.Int())
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pq.Push(rand.Int())
+			pq.Pop()
+		}
+	})
+}
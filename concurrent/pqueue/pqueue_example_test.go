@@ -0,0 +1,60 @@
+package pqueue_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/khavishbhundoo/collections/concurrent/pqueue"
+)
+
+func ExamplePQueue() {
+	// NewOrderedMax pops the largest item first
+	pq := pqueue.NewOrderedMax[int]()
+	pq.PushMany(5, 1, 4)
+	pq.Push(2)
+
+	val, ok := pq.Peek()
+	fmt.Println(val, ok)
+	fmt.Println(pq.Len())
+	for pq.Len() > 0 {
+		val, _ = pq.Pop()
+		fmt.Println(val)
+	}
+	val, ok = pq.Pop()
+	fmt.Println(val, ok)
+
+	// Output:
+	// 5 true
+	// 4
+	// 5
+	// 4
+	// 2
+	// 1
+	// 0 false
+}
+
+func ExamplePQueue_PopWait() {
+	pq := pqueue.NewOrdered[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	pq.PushMany(3, 1, 2)
+
+	// PopWait returns the items in priority order, then blocks
+	// on the empty queue until the context is done
+	for {
+		v, err := pq.PopWait(ctx)
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// context deadline exceeded
+}
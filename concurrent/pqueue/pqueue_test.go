@@ -0,0 +1,358 @@
+package pqueue
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func TestPQueue_New(t *testing.T) {
+	pq := NewOrdered[int]()
+	if cap(pq.items) != 0 {
+		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(pq.items))
+	}
+
+	pq.Push(1)
+	r, ok := pq.Pop()
+	if !ok || r != 1 {
+		t.Errorf("Pop(): expected %d, got %d", 1, r)
+	}
+}
+
+func TestPQueue_NewWithCapacity(t *testing.T) {
+	pq := NewWithCapacity(5, cmp.Less[int])
+	pq.Push(1)
+
+	if pq.Len() != 1 {
+		t.Errorf("Len(): expected %d, got %d", 1, pq.Len())
+	}
+	if cap(pq.items) != 5 {
+		t.Errorf("Initial capacity: expected %d, got %d", 5, cap(pq.items))
+	}
+}
+
+func TestPQueue_PopOrder(t *testing.T) {
+	pq := NewOrdered[int]()
+	values := rand.Perm(1000)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	if r, ok := pq.Peek(); !ok || r != 0 {
+		t.Errorf("Peek(): expected %d, got %d", 0, r)
+	}
+	for i := 0; i < 1000; i++ {
+		r, ok := pq.Pop()
+		if !ok || r != i {
+			t.Fatalf("Pop(): expected %d, got %d", i, r)
+		}
+	}
+	if _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() on empty queue: expected NOK, got OK")
+	}
+}
+
+func TestPQueue_NewOrderedMax(t *testing.T) {
+	pq := NewOrderedMax[string]()
+	pq.PushMany("b", "d", "a", "c")
+	for _, val := range []string{"d", "c", "b", "a"} {
+		r, ok := pq.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %q, got %q", val, r)
+		}
+	}
+}
+
+func TestPQueue_CustomLess(t *testing.T) {
+	pq := New(func(a, b task) bool { return a.priority > b.priority })
+	pq.Push(task{"low", 1})
+	pq.Push(task{"high", 10})
+	pq.Push(task{"mid", 5})
+
+	for _, name := range []string{"high", "mid", "low"} {
+		r, ok := pq.Pop()
+		if !ok || r.name != name {
+			t.Errorf("Pop(): expected %q, got %q", name, r.name)
+		}
+	}
+}
+
+func TestPQueue_PushMany(t *testing.T) {
+	pq := NewOrdered[int]()
+	pq.PushMany(5, 3, 8) // more new items than existing ones: heap is rebuilt
+	pq.PushMany(1)       // fewer new items: sifted up one by one
+	pq.PushMany()
+
+	got := make([]int, 0, 4)
+	for pq.Len() > 0 {
+		r, _ := pq.Pop()
+		got = append(got, r)
+	}
+	if !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("Pop order: expected %v, got %v", []int{1, 3, 5, 8}, got)
+	}
+}
+
+func TestPQueue_NilLess(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"New(nil)", func() { New[int](nil) }},
+		{"NewWithCapacity(8, nil)", func() { NewWithCapacity[int](8, nil) }},
+		{"FromSeq(seq, nil)", func() {
+			FromSeq(func(yield func(int) bool) {
+				t.Errorf("FromSeq(seq, nil): seq read before rejecting the nil less function")
+			}, nil)
+		}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != "pqueue: nil less function" {
+					t.Errorf("%s: expected a panic about the nil less function, got %v", tt.name, r)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
+
+func TestPQueue_ZeroValue(t *testing.T) {
+	var pq PQueue[int]
+	if _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() on zero value: expected NOK, got OK")
+	}
+	if _, ok := pq.Peek(); ok {
+		t.Errorf("Peek() on zero value: expected NOK, got OK")
+	}
+	if pq.Len() != 0 {
+		t.Errorf("Len() on zero value: expected %d, got %d", 0, pq.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Push() on zero value: expected panic")
+		}
+	}()
+	pq.Push(1)
+}
+
+func TestPQueue_PopReleasesReference(t *testing.T) {
+	pq := New(func(a, b *int) bool { return *a < *b })
+	v1, v2 := 1, 2
+	pq.Push(&v1)
+	pq.Push(&v2)
+	pq.Pop()
+	pq.Pop()
+
+	for i, p := range pq.items[:cap(pq.items)] {
+		if p != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestPQueue_Reset(t *testing.T) {
+	pq := NewWithCapacity(5, cmp.Less[int])
+	pq.PushMany(1, 2)
+
+	pq.Reset()
+	if _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() after Reset(): expected NOK, got OK")
+	}
+	if cap(pq.items) != 5 {
+		t.Errorf("Capacity after Reset(): expected %d, got %d", 5, cap(pq.items))
+	}
+}
+
+func TestPQueue_Clear(t *testing.T) {
+	pq := NewWithCapacity(2, cmp.Less[int])
+	pq.PushMany(1, 2, 3)
+
+	pq.Clear()
+	if _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() after Clear(): expected NOK, got OK")
+	}
+	if cap(pq.items) != 2 {
+		t.Errorf("Capacity after Clear(): expected %d, got %d", 2, cap(pq.items))
+	}
+
+	// Clear keeps the ordering
+	pq.PushMany(3, 1, 2)
+	if r, _ := pq.Peek(); r != 1 {
+		t.Errorf("Peek() after Clear(): expected %d, got %d", 1, r)
+	}
+}
+
+func TestPQueue_Shrink(t *testing.T) {
+	initialCap := 64
+	pq := NewWithCapacity(initialCap, cmp.Less[int])
+
+	for i := 0; i < 200; i++ {
+		pq.Push(i)
+	}
+	peakCap := cap(pq.items)
+
+	for i := 0; i < 190; i++ {
+		pq.Pop()
+	}
+
+	if cap(pq.items) >= peakCap {
+		t.Errorf("Expected capacity to shrink below peak %d, got %d", peakCap, cap(pq.items))
+	}
+	if cap(pq.items) < initialCap {
+		t.Errorf("Expected capacity to stay at or above initial capacity %d, got %d", initialCap, cap(pq.items))
+	}
+
+	for i := 190; i < 200; i++ {
+		r, ok := pq.Pop()
+		if !ok || r != i {
+			t.Errorf("Expected Pop() to return %d, got %d", i, r)
+		}
+	}
+}
+
+func TestPQueue_All(t *testing.T) {
+	pq := NewOrdered[int]()
+	pq.PushMany(3, 1, 4, 1, 5, 9, 2, 6)
+
+	if got := slices.Collect(pq.All()); !slices.Equal(got, []int{1, 1, 2, 3, 4, 5, 6, 9}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 1, 2, 3, 4, 5, 6, 9}, got)
+	}
+	if got := slices.Collect(pq.Backward()); !slices.Equal(got, []int{9, 6, 5, 4, 3, 2, 1, 1}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{9, 6, 5, 4, 3, 2, 1, 1}, got)
+	}
+	if pq.Len() != 8 {
+		t.Errorf("Len() after iterating: expected %d, got %d", 8, pq.Len())
+	}
+	if r, _ := pq.Peek(); r != 1 {
+		t.Errorf("Peek() after iterating: expected %d, got %d", 1, r)
+	}
+}
+
+func TestPQueue_FromSeq(t *testing.T) {
+	pq := FromSeq(slices.Values(rand.Perm(100)), cmp.Less[int])
+	for i := 0; i < 100; i++ {
+		r, ok := pq.Pop()
+		if !ok || r != i {
+			t.Fatalf("Pop(): expected %d, got %d", i, r)
+		}
+	}
+}
+
+func TestPQueue_AllSnapshot(t *testing.T) {
+	pq := NewOrdered[int]()
+	pq.PushMany(2, 1)
+
+	// The loop body may modify the queue without deadlocking,
+	// and the iteration is not affected by the modifications.
+	var got []int
+	for v := range pq.All() {
+		pq.Push(v - 10)
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2}, got)
+	}
+	if pq.Len() != 4 {
+		t.Errorf("Len(): expected %d, got %d", 4, pq.Len())
+	}
+}
+
+func TestPQueue_ConcurrentPushPop(t *testing.T) {
+	const goroutines = 10
+	const pushesPerG = 500
+	pq := NewOrdered[int]()
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(base int) {
+			defer wg.Done()
+			for i := 0; i < pushesPerG; i++ {
+				pq.Push(base + i)
+			}
+		}(g * pushesPerG)
+	}
+	wg.Wait()
+
+	if pq.Len() != goroutines*pushesPerG {
+		t.Fatalf("Len(): expected %d, got %d", goroutines*pushesPerG, pq.Len())
+	}
+
+	// Each goroutine must see its own pops in increasing order
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			prev := -1
+			for i := 0; i < pushesPerG; i++ {
+				r, ok := pq.Pop()
+				if !ok {
+					t.Errorf("Pop(): expected OK, got NOK")
+					return
+				}
+				if r <= prev {
+					t.Errorf("Pop(): got %d after %d", r, prev)
+				}
+				prev = r
+			}
+		}()
+	}
+	wg.Wait()
+
+	if pq.Len() != 0 {
+		t.Errorf("Expected empty queue, got Len() = %d", pq.Len())
+	}
+}
+
+func TestPQueue_PopWait(t *testing.T) {
+	pq := NewOrdered[int]()
+
+	done := make(chan int)
+	go func() {
+		v, err := pq.PopWait(context.Background())
+		if err != nil {
+			t.Errorf("PopWait(): unexpected error %v", err)
+		}
+		done <- v
+	}()
+
+	select {
+	case v := <-done:
+		t.Fatalf("PopWait(): returned %d before an item was pushed", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pq.Push(42)
+	if v := <-done; v != 42 {
+		t.Errorf("PopWait(): expected %d, got %d", 42, v)
+	}
+}
+
+func TestPQueue_PopWaitCancel(t *testing.T) {
+	pq := NewOrdered[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := pq.PopWait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PopWait(): expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// The highest-priority item already in the queue is returned without waiting
+	pq.PushMany(2, 1)
+	if v, err := pq.PopWait(context.Background()); err != nil || v != 1 {
+		t.Errorf("PopWait(): expected %d, got %d (err=%v)", 1, v, err)
+	}
+}
@@ -0,0 +1,39 @@
+package queue
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The items are written
+// in FIFO order, front first, in a compact versioned format, from a
+// snapshot taken under the read lock.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// Because Queue must not be copied, only a *Queue implements
+// encoding.BinaryMarshaler and gob.GobEncoder.
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, q.snapshot())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the queue with data encoded by MarshalBinary. On error the
+// queue is not modified.
+// Like Push, it wakes up goroutines blocked in PopWait and ignores the
+// bound of a queue created with NewBounded. It returns ErrClosed without
+// modifying anything if the queue is closed.
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	return q.replace(items)
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (q *Queue[T]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (q *Queue[T]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The queue is encoded as a JSON
+// array holding its items in FIFO order, front first. The items are copied
+// under the read lock, so the encoding is a consistent snapshot even while
+// other goroutines modify the queue. An empty queue encodes as [].
+//
+// Because Queue must not be copied, only a *Queue implements json.Marshaler.
+// A struct holding a Queue by value must be encoded through a pointer, as
+// in json.Marshal(&v): json.Marshal(v) copies the lock, which go vet
+// reports, and encodes the queue as {}. Embedding a Queue promotes
+// MarshalJSON to the embedding struct, which then encodes as the queue
+// alone; use a named field to keep the other fields.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.snapshot())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the queue with the items of a JSON array, the first element becoming the
+// front of the queue, and wakes up goroutines blocked in PopWait. Like Push,
+// it ignores the bound of a queue created with NewBounded. As is
+// conventional, a JSON null leaves the queue unchanged.
+// Returns ErrClosed without modifying anything if the queue is closed.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	return q.replace(items)
+}
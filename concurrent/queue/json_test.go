@@ -0,0 +1,135 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_JSON(t *testing.T) {
+	type response struct {
+		Jobs *Queue[string] `json:"jobs"`
+	}
+	r := response{Jobs: New[string]()}
+	r.Jobs.PushMany("a", "b")
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != `{"jobs":["a","b"]}` {
+		t.Errorf("Marshal(): expected %s, got %s", `{"jobs":["a","b"]}`, data)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.Jobs.All()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Unmarshal(): expected %v, got %v", []string{"a", "b"}, got)
+	}
+
+	data, _ = json.Marshal(New[int]())
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty queue: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestQueue_UnmarshalJSONWakesPopWait(t *testing.T) {
+	q := New[int]()
+	done := make(chan int)
+	go func() {
+		v, _ := q.PopWait(context.Background())
+		done <- v
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if err := json.Unmarshal([]byte("[5]"), q); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	select {
+	case v := <-done:
+		if v != 5 {
+			t.Errorf("PopWait(): expected %d, got %d", 5, v)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Unmarshal() did not wake up PopWait")
+	}
+
+	q.Close()
+	if err := json.Unmarshal([]byte("[1]"), q); !errors.Is(err, ErrClosed) {
+		t.Errorf("Unmarshal() after Close(): expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestQueue_MarshalJSONSnapshot(t *testing.T) {
+	q := New[int]()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			q.Push(i)
+		}
+	}()
+
+	// Every encoding must be a prefix of 0, 1, 2, ...
+	for i := 0; i < 50; i++ {
+		data, err := json.Marshal(q)
+		if err != nil {
+			t.Fatalf("Marshal(): unexpected error %v", err)
+		}
+		var items []int
+		if err := json.Unmarshal(data, &items); err != nil {
+			t.Fatalf("Marshal() produced invalid JSON: %v", err)
+		}
+		for j, v := range items {
+			if v != j {
+				t.Fatalf("Marshal(): item %d is %d", j, v)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func TestQueue_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string        `json:"name"`
+		Jobs Queue[string] `json:"jobs"`
+	}
+	var c config
+	c.Name = "app"
+	c.Jobs.Push("a")
+
+	// A field held by value is encoded through a pointer to its struct
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","jobs":["a"]}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// Map values are not addressable, so only the default encoding applies
+	data, _ = json.Marshal(map[string]config{"x": {Name: "app"}})
+	if want := `{"x":{"name":"app","jobs":{}}}`; string(data) != want {
+		t.Errorf("Marshal() of a non-addressable field: expected %s, got %s", want, data)
+	}
+
+	// An embedded Queue encodes as the whole struct
+	type embedded struct {
+		Queue[string]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.Push("a")
+	data, _ = json.Marshal(&e)
+	if string(data) != `["a"]` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `["a"]`, data)
+	}
+}
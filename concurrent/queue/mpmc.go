@@ -0,0 +1,202 @@
+package queue
+
+import (
+	"context"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultMPMCCapacity is the capacity of the zero value of MPMC and of
+// NewMPMC when a non-positive capacity is given.
+const defaultMPMCCapacity = 1024
+
+// MPMC is a generic, lock-free, bounded FIFO queue that is safe for
+// concurrent use by multiple producers and multiple consumers. It is
+// an array of sequence-numbered slots, after Dmitry Vyukov's bounded
+// MPMC queue: producers and consumers each claim a slot with a single
+// compare-and-swap and never wait for a lock, so a stalled goroutine
+// cannot block the others.
+// The zero value of MPMC[T] is ready to use and holds up to 1024 items.
+//
+// Use NewMPMC() to choose the capacity, which is fixed for the lifetime
+// of the queue. TryPush and TryPop never block; PushWait and PopWait
+// poll them with a backoff until they succeed or the context is done.
+//
+// Prefer Queue when the number of items is unbounded or when goroutines
+// should sleep on an empty queue rather than poll it.
+type MPMC[T any] struct {
+	_     noCopy // prevent accidental copy after first use
+	once  sync.Once
+	slots []slot[T]
+	mask  uint64
+	_     [64]byte      // keep the indices below off the cache line of the fields above
+	tail  atomic.Uint64 // position of the next push
+	_     [56]byte      // keep head and tail on separate cache lines
+	head  atomic.Uint64 // position of the next pop
+	_     [56]byte
+}
+
+// slot holds one item of an MPMC queue. seq tells which operation may use
+// the slot next: a push at position pos when seq == pos, or a pop at
+// position pos when seq == pos+1.
+type slot[T any] struct {
+	seq  atomic.Uint64
+	item T
+}
+
+// NewMPMC creates an empty lock-free queue of type T that holds up to
+// capacity items, rounded up to a power of two of at least 2.
+// A non-positive capacity selects the default.
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	q := &MPMC[T]{}
+	q.once.Do(func() { q.init(capacity) })
+	return q
+}
+
+// init allocates the slots. The capacity is rounded up to a power of two
+// so that a position's slot can be selected with a mask. A single slot
+// would make a full slot indistinguishable from a free one a lap ahead,
+// hence the minimum of 2.
+func (q *MPMC[T]) init(capacity int) {
+	if capacity <= 0 {
+		capacity = defaultMPMCCapacity
+	}
+	n := max(1<<bits.Len(uint(capacity-1)), 2)
+	q.slots = make([]slot[T], n)
+	q.mask = uint64(n - 1)
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+}
+
+// ring returns the slots, initializing the queue on first use if it is
+// a zero value.
+func (q *MPMC[T]) ring() []slot[T] {
+	q.once.Do(func() { q.init(defaultMPMCCapacity) })
+	return q.slots
+}
+
+// TryPush adds item to the end of the queue without blocking.
+// It reports false, leaving the queue unchanged, if the queue is full.
+func (q *MPMC[T]) TryPush(item T) bool {
+	slots := q.ring()
+	pos := q.tail.Load()
+	for {
+		s := &slots[pos&q.mask]
+		seq := s.seq.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				s.item = item
+				s.seq.Store(pos + 1) // publish the item to consumers
+				return true
+			}
+			pos = q.tail.Load()
+		case diff < 0:
+			return false // the slot still holds the item from one lap ago
+		default:
+			pos = q.tail.Load() // another producer claimed pos
+		}
+	}
+}
+
+// TryPop removes and returns the element in front of the queue without
+// blocking. The boolean return is false if the queue is empty.
+func (q *MPMC[T]) TryPop() (T, bool) {
+	slots := q.ring()
+	pos := q.head.Load()
+	for {
+		s := &slots[pos&q.mask]
+		seq := s.seq.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				item := s.item
+				var zero T
+				s.item = zero                 // release the reference for the GC
+				s.seq.Store(pos + q.mask + 1) // hand the slot to the producer one lap ahead
+				return item, true
+			}
+			pos = q.head.Load()
+		case diff < 0:
+			var zero T
+			return zero, false // the item for pos has not been published yet
+		default:
+			pos = q.head.Load() // another consumer claimed pos
+		}
+	}
+}
+
+// PushWait adds item to the end of the queue, waiting while the queue
+// is full. It returns ctx.Err() if the context is done first.
+//
+// Waiting goroutines poll the queue, yielding the processor at first
+// and then sleeping for up to a millisecond between attempts.
+func (q *MPMC[T]) PushWait(ctx context.Context, item T) error {
+	for attempt := 0; ; attempt++ {
+		if q.TryPush(item) {
+			return nil
+		}
+		if err := backoff(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// PopWait removes and returns the element in front of the queue, waiting
+// while the queue is empty. It returns ctx.Err() if the context is done
+// first. Like PushWait, it polls the queue with a backoff.
+func (q *MPMC[T]) PopWait(ctx context.Context) (T, error) {
+	for attempt := 0; ; attempt++ {
+		if item, ok := q.TryPop(); ok {
+			return item, nil
+		}
+		if err := backoff(ctx, attempt); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// Len returns the number of items in the queue. When other goroutines
+// push or pop concurrently, the result is only an approximation.
+func (q *MPMC[T]) Len() int {
+	slots := q.ring()
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail <= head {
+		return 0
+	}
+	return min(int(tail-head), len(slots))
+}
+
+// Cap returns the maximum number of items the queue can hold.
+func (q *MPMC[T]) Cap() int {
+	return len(q.ring())
+}
+
+// backoff waits before the next attempt of a polling loop: it yields the
+// processor for the first few attempts, then sleeps for a duration that
+// doubles up to a millisecond. It returns ctx.Err() if the context is done.
+func backoff(ctx context.Context, attempt int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	const yields = 16
+	if attempt < yields {
+		runtime.Gosched()
+		return nil
+	}
+	d := min(time.Microsecond<<min(attempt-yields, 10), time.Millisecond)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,172 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMPMC_New(t *testing.T) {
+	q := NewMPMC[int](5)
+	if q.Cap() != 8 {
+		t.Errorf("Cap(): expected %d, got %d", 8, q.Cap())
+	}
+	if NewMPMC[int](1).Cap() != 2 {
+		t.Errorf("Cap() of NewMPMC(1): expected %d, got %d", 2, NewMPMC[int](1).Cap())
+	}
+	if NewMPMC[int](0).Cap() != defaultMPMCCapacity {
+		t.Errorf("Cap() of NewMPMC(0): expected %d, got %d", defaultMPMCCapacity, NewMPMC[int](0).Cap())
+	}
+
+	var zero MPMC[int] // zero-value
+	if zero.Cap() != defaultMPMCCapacity {
+		t.Errorf("Cap() of zero value: expected %d, got %d", defaultMPMCCapacity, zero.Cap())
+	}
+	if !zero.TryPush(1) {
+		t.Errorf("TryPush() on zero value: expected true, got false")
+	}
+	if r, ok := zero.TryPop(); !ok || r != 1 {
+		t.Errorf("TryPop() on zero value: expected %d, got %d", 1, r)
+	}
+}
+
+func TestMPMC_FullEmpty(t *testing.T) {
+	q := NewMPMC[int](4)
+	if _, ok := q.TryPop(); ok {
+		t.Errorf("TryPop() on empty queue: expected NOK, got OK")
+	}
+	for i := 0; i < 4; i++ {
+		if !q.TryPush(i) {
+			t.Fatalf("TryPush(%d): expected true, got false", i)
+		}
+	}
+	if q.TryPush(4) {
+		t.Errorf("TryPush() on full queue: expected false, got true")
+	}
+	if q.Len() != 4 {
+		t.Errorf("Len(): expected %d, got %d", 4, q.Len())
+	}
+	for i := 0; i < 4; i++ {
+		if r, ok := q.TryPop(); !ok || r != i {
+			t.Errorf("TryPop(): expected %d, got %d", i, r)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, q.Len())
+	}
+}
+
+func TestMPMC_WrapAround(t *testing.T) {
+	q := NewMPMC[int](4)
+	// Go around the ring many times with a partially filled queue
+	next := 0
+	for i := 0; i < 100; i++ {
+		q.TryPush(i * 2)
+		q.TryPush(i*2 + 1)
+		for j := 0; j < 2; j++ {
+			r, ok := q.TryPop()
+			if !ok || r != next {
+				t.Fatalf("TryPop(): expected %d, got %d", next, r)
+			}
+			next++
+		}
+	}
+}
+
+func TestMPMC_PopReleasesReference(t *testing.T) {
+	q := NewMPMC[*int](2)
+	v := 1
+	q.TryPush(&v)
+	q.TryPop()
+	for i := range q.slots {
+		if q.slots[i].item != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestMPMC_Concurrent(t *testing.T) {
+	const producers = 4
+	const consumers = 4
+	const perProducer = 5000
+	q := NewMPMC[int](64)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.PushWait(context.Background(), p*perProducer+i); err != nil {
+					t.Errorf("PushWait(): unexpected error %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	seen := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for i := 0; i < producers*perProducer/consumers; i++ {
+				v, err := q.PopWait(context.Background())
+				if err != nil {
+					t.Errorf("PopWait(): unexpected error %v", err)
+					return
+				}
+				seen[c] = append(seen[c], v)
+			}
+		}(c)
+	}
+	wg.Wait()
+	cwg.Wait()
+
+	// Every item is popped exactly once, and each consumer sees the
+	// items of any single producer in the order they were pushed
+	count := make([]int, producers*perProducer)
+	for _, vals := range seen {
+		last := make([]int, producers)
+		for i := range last {
+			last[i] = -1
+		}
+		for _, v := range vals {
+			count[v]++
+			p := v / perProducer
+			if v <= last[p] {
+				t.Fatalf("Producer %d: got %d after %d", p, v, last[p])
+			}
+			last[p] = v
+		}
+	}
+	for v, n := range count {
+		if n != 1 {
+			t.Fatalf("Item %d popped %d times", v, n)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected empty queue, got Len() = %d", q.Len())
+	}
+}
+
+func TestMPMC_WaitCancel(t *testing.T) {
+	q := NewMPMC[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.PopWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PopWait() on empty queue: expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	q.TryPush(1)
+	q.TryPush(2)
+	if err := q.PushWait(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PushWait() on full queue: expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if r, err := q.PopWait(context.Background()); err != nil || r != 1 {
+		t.Errorf("PopWait(): expected %d, got %d (err=%v)", 1, r, err)
+	}
+}
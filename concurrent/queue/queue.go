@@ -1,22 +1,42 @@
 package queue
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+)
 
 // Queue is a generic, thread-safe FIFO (first-in-first-out) queue
-// implementation backed by a dynamically resizing slice.The zero value
-// of Queue[T] is ready to use without initialization.
+// implementation backed by a dynamically resizing ring buffer. The zero
+// value of Queue[T] is ready to use without initialization.
 //
 // Use New() or NewWithCapacity() if you prefer an explicit constructor
-// or want to set an initial capacity.
+// or want to set an initial capacity. Use NewBounded() to limit the number
+// of items PushWait will let into the queue.
 // All operations on Queue are safe for concurrent use by multiple goroutines.
 // If you do not need thread-safety, use the collections/queue package instead for better performance.
+//
+// Call Close() to shut the queue down: pushes then fail with ErrClosed,
+// while consumers can still drain the remaining items.
 type Queue[T any] struct {
 	_               noCopy // prevent accidental copy after first use
-	items           []T
+	items           []T    // ring buffer; len(items) is the capacity
+	head            int    // index of the front element
+	count           int    // number of elements in the queue
 	initialCapacity int
+	maxLen          int           // bound enforced by PushWait; 0 means unbounded
+	notEmpty        chan struct{} // closed when an item is added; nil if no PopWait is waiting
+	notFull         chan struct{} // closed when an item is removed; nil if no PushWait is waiting
+	closed          bool
 	mu              sync.RWMutex
 }
 
+// ErrClosed is returned by push operations on a queue that has been
+// closed, and by PopWait once a closed queue has been drained.
+var ErrClosed = errors.New("queue: closed")
+
 // shrinkCapacityThreshold defines the minimum slice capacity before
 // shrink operations are considered. Avoids aggressive shrinking for
 // small queues that would just grow again.
@@ -39,25 +59,96 @@ func New[T any]() *Queue[T] {
 // many elements you’ll push.
 func NewWithCapacity[T any](capacity int) *Queue[T] {
 	return &Queue[T]{
-		items:           make([]T, 0, capacity),
+		items:           make([]T, capacity),
 		initialCapacity: capacity,
 	}
 }
 
+// NewBounded creates an empty queue of type T that holds at most maxLen
+// items when filled through PushWait. PushWait blocks while the queue is
+// full, giving producers backpressure. Push and PushMany never block and
+// are not limited by the bound.
+func NewBounded[T any](maxLen int) *Queue[T] {
+	return &Queue[T]{
+		items:           []T{},
+		initialCapacity: 0,
+		maxLen:          maxLen,
+	}
+}
+
+// FromSeq creates a queue holding the values of seq in the order
+// they are produced, so the first value yielded is the front of the queue.
+// slices.Collect(q.All()) does the reverse, and FromSeq(slices.Values(items))
+// rebuilds the same queue from its result.
+func FromSeq[T any](seq iter.Seq[T]) *Queue[T] {
+	q := New[T]()
+	for v := range seq {
+		q.Push(v)
+	}
+	return q
+}
+
 // PushMany pushes one or more items onto the queue in order.
 // Equivalent to calling Push repeatedly but more efficient
 // when adding multiple elements.
-func (q *Queue[T]) PushMany(item ...T) {
+// Returns ErrClosed without adding anything if the queue is closed.
+func (q *Queue[T]) PushMany(item ...T) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	q.items = append(q.items, item...)
+	if q.closed {
+		return ErrClosed
+	}
+	if q.count+len(item) > len(q.items) {
+		q.grow(len(item))
+	}
+	tail := q.index(q.count)
+	n := copy(q.items[tail:], item)
+	copy(q.items, item[n:])
+	q.count += len(item)
+	if len(item) > 0 {
+		signal(&q.notEmpty)
+	}
+	return nil
 }
 
 // Push adds a single item to the end of the queue.
-func (q *Queue[T]) Push(item T) {
+// Returns ErrClosed without adding the item if the queue is closed.
+func (q *Queue[T]) Push(item T) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	q.items = append(q.items, item)
+	if q.closed {
+		return ErrClosed
+	}
+	q.push(item)
+	return nil
+}
+
+// PushWait adds a single item to the end of the queue, blocking while
+// a queue created with NewBounded is full. It returns ctx.Err() if the
+// context is done before there is room for the item, and ErrClosed
+// if the queue is or gets closed. On an unbounded queue PushWait
+// never blocks.
+func (q *Queue[T]) PushWait(ctx context.Context, item T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.maxLen <= 0 || q.count < q.maxLen {
+			q.push(item)
+			q.mu.Unlock()
+			return nil
+		}
+		wait := waitChan(&q.notFull)
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 // Pop removes and returns the element in front of the queue.
@@ -67,15 +158,171 @@ func (q *Queue[T]) Push(item T) {
 func (q *Queue[T]) Pop() (T, bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	if len(q.items) == 0 {
+	return q.pop()
+}
+
+// PopWait removes and returns the element in front of the queue,
+// blocking until one is available. It returns ctx.Err() if the
+// context is done before an item arrives.
+//
+// Once the queue is closed, PopWait keeps returning the remaining
+// items and then ErrClosed to signal the end of the stream.
+func (q *Queue[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if item, ok := q.pop(); ok {
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		wait := waitChan(&q.notEmpty)
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Peek returns the front of the queue without removing it.
+// The boolean return is false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.count == 0 {
 		var zero T
 		return zero, false
 	}
-	item := q.items[0]
-	q.items = q.items[1:]
+	return q.items[q.head], true
+}
+
+// Len returns the current number of items in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.count
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying buffer. This is faster than Clear()
+// when you expect to reuse the same queue size.
+func (q *Queue[T]) Reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	clear(q.items)
+	q.head = 0
+	q.count = 0
+	signal(&q.notFull)
+}
+
+// Clear removes all items and reallocates a buffer with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = make([]T, q.initialCapacity)
+	q.head = 0
+	q.count = 0
+	signal(&q.notFull)
+}
+
+// Close shuts the queue down. Subsequent pushes fail with ErrClosed and
+// every goroutine blocked in PushWait or PopWait is woken up. Items already
+// in the queue stay available to Pop and PopWait, so consumers can drain
+// them before PopWait reports ErrClosed. Calling Close more than once
+// has no further effect.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	signal(&q.notEmpty)
+	signal(&q.notFull)
+}
+
+// Closed reports whether Close has been called on the queue.
+func (q *Queue[T]) Closed() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.closed
+}
+
+// All returns an iterator over the items of the queue in FIFO order,
+// from front to back, without removing them.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the queue; such changes are not
+// seen by the ongoing iteration.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue in reverse
+// order, from back to front, without removing them.
+//
+// Like All, it runs over a snapshot and does not hold the lock while
+// the loop body runs.
+func (q *Queue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(q.snapshot()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the items of the queue in FIFO order.
+func (q *Queue[T]) snapshot() []T {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	items := make([]T, q.count)
+	n := copy(items, q.items[q.head:min(q.head+q.count, len(q.items))])
+	copy(items[n:], q.items[:q.count-n])
+	return items
+}
+
+// push adds item to the end of the queue and wakes up goroutines
+// blocked in PopWait. The caller must hold q.mu.
+func (q *Queue[T]) push(item T) {
+	if q.count == len(q.items) {
+		q.grow(1)
+	}
+	q.items[q.index(q.count)] = item
+	q.count++
+	signal(&q.notEmpty)
+}
+
+// pop removes and returns the front of the queue, shrinking the
+// buffer when appropriate, and wakes up goroutines blocked in PushWait.
+// The caller must hold q.mu.
+func (q *Queue[T]) pop() (T, bool) {
+	var zero T
+	if q.count == 0 {
+		return zero, false
+	}
+	item := q.items[q.head]
+	q.items[q.head] = zero // release the reference for the GC
+	q.head = q.index(1)
+	q.count--
+	signal(&q.notFull)
 
 	// Reduce capacity if:
-	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
+	//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
 	//   - current capacity exceeds 2× the initial capacity (if any),
 	//   - and fewer than 12.5% of elements are in use (cap/8).
 	//
@@ -87,60 +334,95 @@ func (q *Queue[T]) Pop() (T, bool) {
 	// Why halve capacity?
 	//   Halving avoids repeated reallocations while still reclaiming
 	//   unused memory proportionally. It balances memory efficiency and speed.
-	capNow := cap(q.items)
+	capNow := len(q.items)
 	if capNow > shrinkCapacityThreshold &&
 		(q.initialCapacity == 0 || capNow > q.initialCapacity*2) &&
-		len(q.items) < capNow/8 {
+		q.count < capNow/8 {
 
 		newCap := capNow / 2
 		if q.initialCapacity > 0 && newCap < q.initialCapacity {
 			newCap = q.initialCapacity
 		}
 		if newCap != capNow { // only shrink if capacity actually changes
-			newItems := make([]T, len(q.items), newCap)
-			copy(newItems, q.items)
-			q.items = newItems
+			q.resize(newCap)
 		}
 	}
 
 	return item, true
 }
 
-// Peek returns the front of the queue without removing it.
-// The boolean return is false if the queue is empty.
-func (q *Queue[T]) Peek() (T, bool) {
-	q.mu.RLock()
-	defer q.mu.RUnlock()
-	if len(q.items) == 0 {
-		var zero T
-		return zero, false
+// index returns the position in the ring buffer of the element
+// that is offset places behind the front of the queue.
+// The caller must hold q.mu.
+func (q *Queue[T]) index(offset int) int {
+	i := q.head + offset
+	if i >= len(q.items) {
+		i -= len(q.items)
 	}
-	return q.items[0], true
+	return i
 }
 
-// Len returns the current number of items in the queue.
-func (q *Queue[T]) Len() int {
-	q.mu.RLock()
-	defer q.mu.RUnlock()
-	return len(q.items)
+// grow makes room for at least n more elements by doubling the
+// capacity, or more if a single PushMany needs it.
+// The caller must hold q.mu.
+func (q *Queue[T]) grow(n int) {
+	q.resize(max(len(q.items)*2, q.count+n))
 }
 
-// Reset clears all items but keeps the current capacity
-// of the underlying slice. This is faster than Clear()
-// when you expect to reuse the same queue size.
-func (q *Queue[T]) Reset() {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	q.items = q.items[:0]
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the front of the queue is at index 0.
+// The caller must hold q.mu.
+func (q *Queue[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if q.count > 0 {
+		n := copy(newItems, q.items[q.head:min(q.head+q.count, len(q.items))])
+		copy(newItems[n:], q.items[:q.count-n])
+	}
+	q.items = newItems
+	q.head = 0
 }
 
-// Clear removes all items and reallocates a slice with
-// the initial capacity (if any). Use this to shrink the
-// backing array explicitly.
-func (q *Queue[T]) Clear() {
+// replace makes items the contents of the queue, the first item becoming
+// the front, and wakes up goroutines blocked in PopWait or PushWait.
+// The buffer keeps at least the initial capacity. It returns ErrClosed
+// without modifying anything if the queue is closed.
+func (q *Queue[T]) replace(items []T) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	q.items = make([]T, 0, q.initialCapacity)
+	if q.closed {
+		return ErrClosed
+	}
+	if len(items) < q.initialCapacity {
+		items = append(make([]T, 0, q.initialCapacity), items...)
+	}
+	q.items = items[:cap(items)]
+	q.head = 0
+	q.count = len(items)
+	if q.count > 0 {
+		signal(&q.notEmpty)
+	}
+	signal(&q.notFull)
+	return nil
+}
+
+// waitChan returns the channel *ch that is closed on the next signal,
+// creating it if no goroutine is waiting yet. The caller must hold
+// the lock that guards *ch.
+func waitChan(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// signal wakes up every goroutine waiting on *ch by closing it.
+// It is a no-op when nobody is waiting. The caller must hold the
+// lock that guards *ch.
+func signal(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
 }
 
 // noCopy may be added to structs which must not be copied
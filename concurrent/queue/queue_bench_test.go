@@ -130,3 +130,218 @@ func BenchmarkQueue_ResetAndPush10MWithCapacity(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkQueue_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 1000; i++ {
+		s.Push(i)
+	}
+	for b.Loop() {
+		s.Push(1)
+		s.Pop()
+	}
+}
+
+func BenchmarkQueue_ConcurrentSteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 1000; i++ {
+		s.Push(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
+
+// The MPMC benchmarks mirror the Queue ones above. MPMC is bounded, so
+// the Push and Pop benchmarks drain or refill it, untimed, when needed.
+const benchMPMCCapacity = 1 << 16
+
+func BenchmarkMPMC_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewMPMC[int](benchMPMCCapacity)
+	for b.Loop() {
+		if !q.TryPush(1) {
+			b.StopTimer()
+			for _, ok := q.TryPop(); ok; _, ok = q.TryPop() {
+			}
+			b.StartTimer()
+			q.TryPush(1)
+		}
+	}
+}
+
+func BenchmarkMPMC_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewMPMC[int](benchMPMCCapacity)
+	for b.Loop() {
+		if _, ok := q.TryPop(); !ok {
+			b.StopTimer()
+			for q.TryPush(1) {
+			}
+			b.StartTimer()
+			q.TryPop()
+		}
+	}
+}
+
+func BenchmarkMPMC_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewMPMC[int](benchMPMCCapacity)
+	for i := 0; i < 1000; i++ {
+		q.TryPush(i)
+	}
+	for b.Loop() {
+		q.TryPush(1)
+		q.TryPop()
+	}
+}
+
+func BenchmarkMPMC_ConcurrentSteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewMPMC[int](benchMPMCCapacity)
+	for i := 0; i < 1000; i++ {
+		q.TryPush(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.TryPush(1)
+			q.TryPop()
+		}
+	})
+}
+
+// The SPSC benchmarks mirror the MPMC ones, with a single producer and
+// a single consumer. The ProducerConsumer benchmarks run them in separate
+// goroutines, as an SPSC queue is meant to be used.
+func BenchmarkSPSC_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewSPSC[int](benchMPMCCapacity)
+	for b.Loop() {
+		if !q.Push(1) {
+			b.StopTimer()
+			for _, ok := q.Pop(); ok; _, ok = q.Pop() {
+			}
+			b.StartTimer()
+			q.Push(1)
+		}
+	}
+}
+
+func BenchmarkSPSC_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewSPSC[int](benchMPMCCapacity)
+	for b.Loop() {
+		if _, ok := q.Pop(); !ok {
+			b.StopTimer()
+			for q.Push(1) {
+			}
+			b.StartTimer()
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkSPSC_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := NewSPSC[int](benchMPMCCapacity)
+	for i := 0; i < 1000; i++ {
+		q.Push(i)
+	}
+	for b.Loop() {
+		q.Push(1)
+		q.Pop()
+	}
+}
+
+func BenchmarkSPSC_ProducerConsumer(b *testing.B) {
+	b.ReportAllocs()
+	q := NewSPSC[int](1024)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < b.N; {
+			if _, ok := q.Pop(); ok {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+	b.ResetTimer()
+	for i := 0; i < b.N; {
+		if q.Push(i) {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+}
+
+func BenchmarkSPSC_ProducerConsumerBatch(b *testing.B) {
+	b.ReportAllocs()
+	q := NewSPSC[int](1024)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dst := make([]int, 64)
+		for i := 0; i < b.N; {
+			if n := q.PopMany(dst[:min(len(dst), b.N-i)]); n > 0 {
+				i += n
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+	batch := make([]int, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; {
+		if n := q.PushMany(batch[:min(len(batch), b.N-i)]...); n > 0 {
+			i += n
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+}
+
+func BenchmarkMPMC_ProducerConsumer(b *testing.B) {
+	b.ReportAllocs()
+	q := NewMPMC[int](1024)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < b.N; {
+			if _, ok := q.TryPop(); ok {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+	b.ResetTimer()
+	for i := 0; i < b.N; {
+		if q.TryPush(i) {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+}
@@ -1,7 +1,9 @@
 package queue_test
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/khavishbhundoo/collections/concurrent/queue"
@@ -60,3 +62,93 @@ func ExampleQueue() {
 	// 1 true
 	// 3
 }
+
+func ExampleQueue_PopWait() {
+	// A bounded queue gives producers backpressure through PushWait
+	q := queue.NewBounded[int](2)
+	ctx := context.Background()
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			_ = q.PushWait(ctx, i)
+		}
+	}()
+
+	// PopWait blocks until the producer has pushed an item
+	for i := 0; i < 5; i++ {
+		v, err := q.PopWait(ctx)
+		fmt.Println(v, err)
+	}
+
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// 3 <nil>
+	// 4 <nil>
+	// 5 <nil>
+}
+
+func ExampleMPMC() {
+	q := queue.NewMPMC[int](2)
+	fmt.Println(q.Cap())
+
+	fmt.Println(q.TryPush(1), q.TryPush(2))
+	fmt.Println(q.TryPush(3)) // the queue is full
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// PushWait waits until a consumer makes room
+		_ = q.PushWait(context.Background(), 3)
+	}()
+
+	for i := 0; i < 3; i++ {
+		v, err := q.PopWait(context.Background())
+		fmt.Println(v, err)
+	}
+	wg.Wait()
+
+	// Output:
+	// 2
+	// true true
+	// false
+	// 1 <nil>
+	// 2 <nil>
+	// 3 <nil>
+}
+
+func ExampleSPSC() {
+	q := queue.NewSPSC[int](4)
+	fmt.Println(q.Cap())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// The producer publishes a batch, then single items
+		q.PushMany(1, 2, 3)
+		for i := 4; i <= 6; {
+			if q.Push(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	var got []int
+	buf := make([]int, 4)
+	for len(got) < 6 {
+		n := q.PopMany(buf)
+		if n == 0 {
+			runtime.Gosched() // let the producer run
+		}
+		got = append(got, buf[:n]...)
+	}
+	<-done
+	fmt.Println(got, q.Len())
+
+	// Output:
+	// 4
+	// [1 2 3 4 5 6] 0
+}
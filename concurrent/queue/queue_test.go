@@ -1,13 +1,21 @@
 package queue
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestQueue_New(t *testing.T) {
 	q := New[int]()
+	if cap(q.items) != 0 {
+		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(q.items))
+	}
+
 	q.Push(1)
 
 	r, ok := q.Pop()
@@ -17,10 +25,6 @@ func TestQueue_New(t *testing.T) {
 	if r != 1 {
 		t.Errorf("Pop(): expected %d, got %d", 1, r)
 	}
-
-	if cap(q.items) != 0 {
-		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(q.items))
-	}
 }
 
 func TestQueue_NewWithCapacity(t *testing.T) {
@@ -165,15 +169,15 @@ func TestQueue_Shrink(t *testing.T) {
 	initialCap := 64
 	q := NewWithCapacity[int](initialCap)
 
-	// Fill the queue
-	for i := 0; i < 100; i++ {
+	// Fill the queue past twice its initial capacity
+	for i := 0; i < 200; i++ {
 		q.Push(i)
 	}
 
 	peakCap := cap(q.items)
 
 	// Pop most of the items to trigger shrink (less than 25% used)
-	for i := 0; i < 90; i++ {
+	for i := 0; i < 190; i++ {
 		_, ok := q.Pop()
 		if !ok {
 			t.Fatalf("Pop() failed at iteration %d", i)
@@ -190,7 +194,7 @@ func TestQueue_Shrink(t *testing.T) {
 	}
 
 	// Remaining elements should still be correct
-	for _, val := range []int{90, 91, 92, 93, 94, 95, 96, 97, 98, 99} {
+	for _, val := range []int{190, 191, 192, 193, 194, 195, 196, 197, 198, 199} {
 		r, ok := q.Pop()
 		if !ok || r != val {
 			t.Errorf("Expected Pop() to return %d, got %d", val, r)
@@ -202,6 +206,20 @@ func TestQueue_Shrink(t *testing.T) {
 	}
 }
 
+func TestQueue_ShrinkKeepsTwiceInitialCapacity(t *testing.T) {
+	// A buffer that has only doubled from its initial capacity is not shrunk
+	q := NewWithCapacity[int](64)
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < 95; i++ {
+		q.Pop()
+	}
+	if cap(q.items) != 128 {
+		t.Errorf("Expected capacity to stay at %d, got %d", 128, cap(q.items))
+	}
+}
+
 func TestQueue_GenericType(t *testing.T) {
 	q := New[string]()
 	q.Push("foo")
@@ -218,6 +236,120 @@ func TestQueue_GenericType(t *testing.T) {
 	}
 }
 
+func TestQueue_WrapAround(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(1, 2, 3)
+	q.Pop()
+	q.Pop()
+
+	// The tail wraps to the start of the buffer without growing it
+	q.PushMany(4, 5, 6)
+	if cap(q.items) != 4 {
+		t.Errorf("Capacity after wrap: expected %d, got %d", 4, cap(q.items))
+	}
+
+	// Growing a wrapped buffer must keep FIFO order
+	q.PushMany(7, 8)
+	for _, val := range []int{3, 4, 5, 6, 7, 8} {
+		r, ok := q.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+}
+
+func TestQueue_PopReleasesReference(t *testing.T) {
+	q := NewWithCapacity[*int](4)
+	v := 1
+	q.Push(&v)
+	q.Pop()
+
+	for i, p := range q.items {
+		if p != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestQueue_SteadyPushPopNoAllocs(t *testing.T) {
+	q := NewWithCapacity[int](8)
+	q.PushMany(1, 2, 3)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		q.Push(1)
+		q.Pop()
+	})
+	if allocs != 0 {
+		t.Errorf("Push/Pop: expected 0 allocations, got %v", allocs)
+	}
+}
+
+func TestQueue_All(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(0, 1, 2)
+	q.Pop()
+	q.PushMany(3, 4) // wraps around the ring buffer
+
+	got := slices.Collect(q.All())
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3, 4}, got)
+	}
+	if q.Len() != 4 {
+		t.Errorf("Len() after All(): expected %d, got %d", 4, q.Len())
+	}
+
+	got = slices.Collect(q.Backward())
+	if !slices.Equal(got, []int{4, 3, 2, 1}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{4, 3, 2, 1}, got)
+	}
+
+	for v := range q.All() {
+		if v == 2 {
+			break
+		}
+		if v > 2 {
+			t.Errorf("All(): iteration continued after break, got %d", v)
+		}
+	}
+
+	var zero Queue[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value queue: unexpected %d", v)
+	}
+}
+
+func TestQueue_FromSeq(t *testing.T) {
+	q := FromSeq(slices.Values([]int{1, 2, 3}))
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("slices.Collect(All()): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	for _, val := range []int{1, 2, 3} {
+		r, ok := q.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+}
+
+func TestQueue_AllSnapshot(t *testing.T) {
+	q := New[int]()
+	q.PushMany(1, 2, 3)
+
+	// The loop body may modify the queue without deadlocking,
+	// and the iteration is not affected by the modifications.
+	var got []int
+	for v := range q.All() {
+		q.Push(v * 10)
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	if q.Len() != 6 {
+		t.Errorf("Len(): expected %d, got %d", 6, q.Len())
+	}
+}
+
 func TestQueue_ConcurrentPush(t *testing.T) {
 	const goroutines = 10
 	const perGoroutine = 1000
@@ -328,3 +460,222 @@ func TestQueue_ConcurrentPushPop(t *testing.T) {
 		t.Errorf("Expected empty queue, got Len() = %d", q.Len())
 	}
 }
+
+func TestQueue_PopWait(t *testing.T) {
+	q := New[int]()
+
+	done := make(chan int)
+	go func() {
+		v, err := q.PopWait(context.Background())
+		if err != nil {
+			t.Errorf("PopWait(): unexpected error %v", err)
+		}
+		done <- v
+	}()
+
+	select {
+	case v := <-done:
+		t.Fatalf("PopWait(): returned %d before an item was pushed", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	q.Push(42)
+	if v := <-done; v != 42 {
+		t.Errorf("PopWait(): expected %d, got %d", 42, v)
+	}
+}
+
+func TestQueue_PopWaitCancel(t *testing.T) {
+	q := New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := q.PopWait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PopWait(): expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// An item already in the queue is returned without waiting
+	q.Push(1)
+	if v, err := q.PopWait(context.Background()); err != nil || v != 1 {
+		t.Errorf("PopWait(): expected %d, got %d (err=%v)", 1, v, err)
+	}
+}
+
+func TestQueue_PushWait(t *testing.T) {
+	q := NewBounded[int](2)
+	ctx := context.Background()
+
+	if err := q.PushWait(ctx, 1); err != nil {
+		t.Fatalf("PushWait(): unexpected error %v", err)
+	}
+	if err := q.PushWait(ctx, 2); err != nil {
+		t.Fatalf("PushWait(): unexpected error %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- q.PushWait(ctx, 3)
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("PushWait(): returned while the queue was full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if v, _ := q.Pop(); v != 1 {
+		t.Errorf("Pop(): expected %d, got %d", 1, v)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("PushWait(): unexpected error %v", err)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{2, 3}, got)
+	}
+}
+
+func TestQueue_PushWaitCancel(t *testing.T) {
+	q := NewBounded[int](1)
+	q.Push(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := q.PushWait(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("PushWait(): expected %v, got %v", context.Canceled, err)
+	}
+	if q.Len() != 1 {
+		t.Errorf("Len(): expected %d, got %d", 1, q.Len())
+	}
+
+	// Reset makes room for blocked producers
+	q.Reset()
+	if err := q.PushWait(context.Background(), 2); err != nil {
+		t.Errorf("PushWait() after Reset(): unexpected error %v", err)
+	}
+
+	// An unbounded queue never blocks
+	var u Queue[int]
+	for i := 0; i < 100; i++ {
+		if err := u.PushWait(ctx, i); err != nil {
+			t.Fatalf("PushWait() on unbounded queue: unexpected error %v", err)
+		}
+	}
+}
+
+func TestQueue_ConcurrentPushWaitPopWait(t *testing.T) {
+	const producers = 4
+	const consumers = 4
+	const perProducer = 1000
+	const maxLen = 8
+
+	q := NewBounded[int](maxLen)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.PushWait(ctx, 1); err != nil {
+					t.Errorf("PushWait(): unexpected error %v", err)
+				}
+				if n := q.Len(); n > maxLen {
+					t.Errorf("Len(): expected at most %d, got %d", maxLen, n)
+				}
+			}
+		}()
+	}
+
+	var sum atomic.Int64
+	var cwg sync.WaitGroup
+	cwg.Add(consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			defer cwg.Done()
+			for i := 0; i < producers*perProducer/consumers; i++ {
+				v, err := q.PopWait(ctx)
+				if err != nil {
+					t.Errorf("PopWait(): unexpected error %v", err)
+				}
+				sum.Add(int64(v))
+			}
+		}()
+	}
+
+	wg.Wait()
+	cwg.Wait()
+
+	if sum.Load() != producers*perProducer {
+		t.Errorf("Expected %d items consumed, got %d", producers*perProducer, sum.Load())
+	}
+}
+
+func TestQueue_Close(t *testing.T) {
+	q := New[int]()
+	q.PushMany(1, 2)
+	q.Close()
+	q.Close() // closing twice is a no-op
+
+	if !q.Closed() {
+		t.Errorf("Closed(): expected true, got false")
+	}
+	if err := q.Push(3); !errors.Is(err, ErrClosed) {
+		t.Errorf("Push() after Close(): expected %v, got %v", ErrClosed, err)
+	}
+	if err := q.PushMany(3, 4); !errors.Is(err, ErrClosed) {
+		t.Errorf("PushMany() after Close(): expected %v, got %v", ErrClosed, err)
+	}
+	if err := q.PushWait(context.Background(), 3); !errors.Is(err, ErrClosed) {
+		t.Errorf("PushWait() after Close(): expected %v, got %v", ErrClosed, err)
+	}
+
+	// Remaining items are drained before the end of the stream is reported
+	for _, val := range []int{1, 2} {
+		v, err := q.PopWait(context.Background())
+		if err != nil || v != val {
+			t.Errorf("PopWait(): expected %d, got %d (err=%v)", val, v, err)
+		}
+	}
+	if _, err := q.PopWait(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("PopWait() on drained queue: expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestQueue_CloseWakesWaiters(t *testing.T) {
+	const waiters = 4
+	empty := New[int]()
+	full := NewBounded[int](1)
+	full.Push(1)
+
+	errs := make(chan error, 2*waiters)
+	for i := 0; i < waiters; i++ {
+		go func() {
+			_, err := empty.PopWait(context.Background())
+			errs <- err
+		}()
+		go func() {
+			errs <- full.PushWait(context.Background(), 2)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	empty.Close()
+	full.Close()
+
+	for i := 0; i < 2*waiters; i++ {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, ErrClosed) {
+				t.Errorf("Expected waiter to return %v, got %v", ErrClosed, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Close() did not wake up all waiters")
+		}
+	}
+
+	if full.Len() != 1 {
+		t.Errorf("Len(): expected %d, got %d", 1, full.Len())
+	}
+}
@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultSPSCCapacity is the capacity of the zero value of SPSC and of
+// NewSPSC when a non-positive capacity is given.
+const defaultSPSCCapacity = 1024
+
+// SPSC is a generic, bounded FIFO ring buffer for exactly one producer
+// goroutine and one consumer goroutine. Once the queue is initialized,
+// every operation is wait-free: it completes in a bounded number of steps
+// without locks or retries, and it never allocates.
+//
+// A queue created with NewSPSC is initialized from the start. The zero
+// value of SPSC[T] is also ready to use and holds up to 1024 items, but
+// it allocates its buffer on first use, under a sync.Once: until then,
+// the first Push or Pop may block while the other goroutine initializes
+// the queue. Use NewSPSC where that first operation must be wait-free too.
+//
+// Use NewSPSC() to choose the capacity, which is fixed for the lifetime
+// of the queue. Push and PushMany may only be called by the producer,
+// and Pop and PopMany only by the consumer; Len and Cap may be called
+// by either. Use MPMC or Queue when there is more than one producer
+// or consumer.
+type SPSC[T any] struct {
+	_          noCopy // prevent accidental copy after first use
+	once       sync.Once
+	items      []T
+	mask       uint64
+	_          [64]byte      // keep the indices below off the cache line of the fields above
+	tail       atomic.Uint64 // position of the next push; written by the producer only
+	cachedHead uint64        // the producer's last view of head
+	_          [48]byte      // keep the producer's and consumer's fields on separate cache lines
+	head       atomic.Uint64 // position of the next pop; written by the consumer only
+	cachedTail uint64        // the consumer's last view of tail
+	_          [48]byte
+}
+
+// NewSPSC creates an empty single-producer, single-consumer queue of type
+// T that holds up to capacity items, rounded up to a power of two.
+// A non-positive capacity selects the default.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	q := &SPSC[T]{}
+	q.once.Do(func() { q.init(capacity) })
+	return q
+}
+
+// init allocates the buffer. The capacity is rounded up to a power of two
+// so that a position's index can be selected with a mask.
+func (q *SPSC[T]) init(capacity int) {
+	if capacity <= 0 {
+		capacity = defaultSPSCCapacity
+	}
+	n := 1 << bits.Len(uint(capacity-1))
+	q.items = make([]T, n)
+	q.mask = uint64(n - 1)
+}
+
+// buffer returns the ring buffer, initializing the queue on first use
+// if it is a zero value.
+func (q *SPSC[T]) buffer() []T {
+	q.once.Do(func() { q.init(defaultSPSCCapacity) })
+	return q.items
+}
+
+// Push adds item to the end of the queue and reports whether it did.
+// It returns false, leaving the queue unchanged, if the queue is full.
+// Only the producer goroutine may call Push.
+func (q *SPSC[T]) Push(item T) bool {
+	items := q.buffer()
+	tail := q.tail.Load()
+	if tail-q.cachedHead == uint64(len(items)) {
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead == uint64(len(items)) {
+			return false
+		}
+	}
+	items[tail&q.mask] = item
+	q.tail.Store(tail + 1) // publish the item to the consumer
+	return true
+}
+
+// PushMany adds as many of items to the end of the queue as there is room
+// for, in order, and returns how many it added. All of them are published
+// to the consumer at once. Only the producer goroutine may call PushMany.
+func (q *SPSC[T]) PushMany(items ...T) int {
+	buf := q.buffer()
+	tail := q.tail.Load()
+	free := uint64(len(buf)) - (tail - q.cachedHead)
+	if free < uint64(len(items)) {
+		q.cachedHead = q.head.Load()
+		free = uint64(len(buf)) - (tail - q.cachedHead)
+	}
+	n := min(int(free), len(items))
+	if n == 0 {
+		return 0
+	}
+	i := int(tail & q.mask)
+	m := copy(buf[i:], items[:n])
+	copy(buf, items[m:n])
+	q.tail.Store(tail + uint64(n))
+	return n
+}
+
+// Pop removes and returns the element in front of the queue.
+// The boolean return is false if the queue is empty.
+// Only the consumer goroutine may call Pop.
+func (q *SPSC[T]) Pop() (T, bool) {
+	items := q.buffer()
+	head := q.head.Load()
+	var zero T
+	if head == q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head == q.cachedTail {
+			return zero, false
+		}
+	}
+	i := head & q.mask
+	item := items[i]
+	items[i] = zero        // release the reference for the GC
+	q.head.Store(head + 1) // hand the slot back to the producer
+	return item, true
+}
+
+// PopMany removes up to len(dst) elements from the front of the queue,
+// stores them in dst in FIFO order and returns how many it removed.
+// The slots are handed back to the producer at once.
+// Only the consumer goroutine may call PopMany.
+func (q *SPSC[T]) PopMany(dst []T) int {
+	buf := q.buffer()
+	head := q.head.Load()
+	avail := q.cachedTail - head
+	if avail < uint64(len(dst)) {
+		q.cachedTail = q.tail.Load()
+		avail = q.cachedTail - head
+	}
+	n := min(int(avail), len(dst))
+	if n == 0 {
+		return 0
+	}
+	i := int(head & q.mask)
+	m := copy(dst[:n], buf[i:])
+	copy(dst[m:n], buf)
+	// release the references for the GC
+	clear(buf[i : i+m])
+	clear(buf[:n-m])
+	q.head.Store(head + uint64(n))
+	return n
+}
+
+// Len returns the number of items in the queue. While the producer or
+// the consumer is active, the result is only an approximation.
+func (q *SPSC[T]) Len() int {
+	items := q.buffer()
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail <= head {
+		return 0
+	}
+	return min(int(tail-head), len(items))
+}
+
+// Cap returns the maximum number of items the queue can hold.
+func (q *SPSC[T]) Cap() int {
+	return len(q.buffer())
+}
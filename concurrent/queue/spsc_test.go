@@ -0,0 +1,213 @@
+package queue
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestSPSC_New(t *testing.T) {
+	q := NewSPSC[int](5)
+	if q.Cap() != 8 {
+		t.Errorf("Cap(): expected %d, got %d", 8, q.Cap())
+	}
+	if NewSPSC[int](1).Cap() != 1 {
+		t.Errorf("Cap() of NewSPSC(1): expected %d, got %d", 1, NewSPSC[int](1).Cap())
+	}
+	if NewSPSC[int](0).Cap() != defaultSPSCCapacity {
+		t.Errorf("Cap() of NewSPSC(0): expected %d, got %d", defaultSPSCCapacity, NewSPSC[int](0).Cap())
+	}
+
+	var zero SPSC[int] // zero-value
+	if zero.Cap() != defaultSPSCCapacity {
+		t.Errorf("Cap() of zero value: expected %d, got %d", defaultSPSCCapacity, zero.Cap())
+	}
+	if !zero.Push(1) {
+		t.Errorf("Push() on zero value: expected true, got false")
+	}
+	if r, ok := zero.Pop(); !ok || r != 1 {
+		t.Errorf("Pop() on zero value: expected %d, got %d", 1, r)
+	}
+}
+
+func TestSPSC_FullEmpty(t *testing.T) {
+	q := NewSPSC[int](4)
+	if _, ok := q.Pop(); ok {
+		t.Errorf("Pop() on empty queue: expected NOK, got OK")
+	}
+	for i := 0; i < 4; i++ {
+		if !q.Push(i) {
+			t.Fatalf("Push(%d): expected true, got false", i)
+		}
+	}
+	if q.Push(4) {
+		t.Errorf("Push() on full queue: expected false, got true")
+	}
+	if q.Len() != 4 {
+		t.Errorf("Len(): expected %d, got %d", 4, q.Len())
+	}
+	for i := 0; i < 4; i++ {
+		if r, ok := q.Pop(); !ok || r != i {
+			t.Errorf("Pop(): expected %d, got %d", i, r)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, q.Len())
+	}
+
+	// A single slot alternates between full and empty
+	one := NewSPSC[int](1)
+	for i := 0; i < 3; i++ {
+		if !one.Push(i) || one.Push(i) {
+			t.Fatalf("Push() on capacity 1: expected exactly one item to fit")
+		}
+		if r, ok := one.Pop(); !ok || r != i {
+			t.Fatalf("Pop(): expected %d, got %d", i, r)
+		}
+	}
+}
+
+func TestSPSC_WrapAround(t *testing.T) {
+	q := NewSPSC[int](4)
+	// Go around the ring many times with a partially filled queue
+	next := 0
+	for i := 0; i < 100; i++ {
+		q.Push(i * 2)
+		q.Push(i*2 + 1)
+		for j := 0; j < 2; j++ {
+			r, ok := q.Pop()
+			if !ok || r != next {
+				t.Fatalf("Pop(): expected %d, got %d", next, r)
+			}
+			next++
+		}
+	}
+}
+
+func TestSPSC_Batch(t *testing.T) {
+	q := NewSPSC[int](8)
+	if n := q.PushMany(); n != 0 {
+		t.Errorf("PushMany() with no items: expected %d, got %d", 0, n)
+	}
+	if n := q.PopMany(make([]int, 4)); n != 0 {
+		t.Errorf("PopMany() on empty queue: expected %d, got %d", 0, n)
+	}
+
+	// Offset the positions so that batches straddle the end of the ring
+	q.PushMany(-1, -1, -1, -1, -1)
+	q.PopMany(make([]int, 5))
+
+	if n := q.PushMany(0, 1, 2, 3, 4, 5, 6, 7, 8, 9); n != 8 {
+		t.Errorf("PushMany() beyond capacity: expected %d, got %d", 8, n)
+	}
+	if q.Len() != 8 {
+		t.Errorf("Len(): expected %d, got %d", 8, q.Len())
+	}
+	dst := make([]int, 3)
+	if n := q.PopMany(dst); n != 3 || dst[0] != 0 || dst[1] != 1 || dst[2] != 2 {
+		t.Errorf("PopMany(): expected 3 items [0 1 2], got %d items %v", n, dst[:n])
+	}
+	if n := q.PushMany(8, 9); n != 2 {
+		t.Errorf("PushMany(): expected %d, got %d", 2, n)
+	}
+	dst = make([]int, 16)
+	n := q.PopMany(dst)
+	if n != 7 {
+		t.Fatalf("PopMany(): expected %d, got %d", 7, n)
+	}
+	for i, v := range dst[:n] {
+		if v != i+3 {
+			t.Errorf("PopMany()[%d]: expected %d, got %d", i, i+3, v)
+		}
+	}
+}
+
+func TestSPSC_PopReleasesReference(t *testing.T) {
+	q := NewSPSC[*int](4)
+	v := 1
+	q.Push(&v)
+	q.Pop()
+	q.PushMany(&v, &v, &v, &v)
+	q.PopMany(make([]*int, 4))
+	for i := range q.items {
+		if q.items[i] != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestSPSC_Concurrent(t *testing.T) {
+	const total = 100000
+	q := NewSPSC[int](64)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		batch := make([]int, 0, 16)
+		for i := 0; i < total; {
+			if i%3 == 0 {
+				if q.Push(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+				continue
+			}
+			batch = batch[:0]
+			for j := i; j < min(i+16, total); j++ {
+				batch = append(batch, j)
+			}
+			if n := q.PushMany(batch...); n > 0 {
+				i += n
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	// The consumer sees every item exactly once, in the order pushed
+	next := 0
+	dst := make([]int, 8)
+	for next < total {
+		if next%2 == 0 {
+			if v, ok := q.Pop(); ok {
+				if v != next {
+					t.Fatalf("Pop(): expected %d, got %d", next, v)
+				}
+				next++
+			} else {
+				runtime.Gosched()
+			}
+			continue
+		}
+		n := q.PopMany(dst)
+		if n == 0 {
+			runtime.Gosched()
+		}
+		for _, v := range dst[:n] {
+			if v != next {
+				t.Fatalf("PopMany(): expected %d, got %d", next, v)
+			}
+			next++
+		}
+	}
+	<-done
+	if q.Len() != 0 {
+		t.Errorf("Expected empty queue, got Len() = %d", q.Len())
+	}
+}
+
+func TestSPSC_NoAllocs(t *testing.T) {
+	q := NewSPSC[int](64)
+	items := []int{1, 2, 3, 4}
+	dst := make([]int, 4)
+	allocs := testing.AllocsPerRun(100, func() {
+		q.Push(1)
+		q.Pop()
+		q.PushMany(items...)
+		q.PopMany(dst)
+		_ = q.Len()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations in steady state, got %v", allocs)
+	}
+}
@@ -0,0 +1,57 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// MarshalJSON implements json.Marshaler. The set is encoded as a JSON
+// array of its elements, in unspecified order unless SortJSON was called.
+// The elements are copied under the read lock, so the encoding is a
+// consistent snapshot even while other goroutines modify the set, and
+// sorted after the lock is released. An empty set encodes as [].
+//
+// Because Set must not be copied, only a *Set implements json.Marshaler.
+// A struct holding a Set by value must be encoded through a pointer, as in
+// json.Marshal(&v): json.Marshal(v) copies the lock, which go vet reports,
+// and encodes the set as {}. Embedding a Set promotes MarshalJSON to the
+// embedding struct, which then encodes as the set alone; use a named field
+// to keep the other fields.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	compare := s.jsonCompare
+	s.mu.RUnlock()
+	items := s.snapshot()
+	if compare != nil {
+		slices.SortFunc(items, compare)
+	}
+	return json.Marshal(items)
+}
+
+// SortJSON makes MarshalJSON encode the elements in ascending order as
+// decided by compare, so that equal sets always produce the same bytes,
+// wherever the set is encoded. Use cmp.Compare for ordered elements, or
+// nil to go back to unspecified order. The setting is kept by Reset,
+// Clear and UnmarshalJSON.
+func (s *Set[T]) SortJSON(compare func(a, b T) int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jsonCompare = compare
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the set with the elements of a JSON array; duplicates are ignored.
+// As is conventional, a JSON null leaves the set unchanged. On error the
+// set is not modified.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	s.replace(values)
+	return nil
+}
@@ -0,0 +1,96 @@
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestSet_JSON(t *testing.T) {
+	s := New[int]()
+	s.AddMany(3, 1, 2)
+
+	s.SortJSON(cmp.Compare[int])
+	sorted, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() after SortJSON(): unexpected error %v", err)
+	}
+	if string(sorted) != "[1,2,3]" {
+		t.Errorf("Marshal() after SortJSON(): expected %s, got %s", "[1,2,3]", sorted)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	decoded := New[int]()
+	decoded.Add(9)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if !decoded.Equal(s) {
+		t.Errorf("Unmarshal(): expected %s, got %d elements", sorted, decoded.Len())
+	}
+
+	data, _ = json.Marshal(New[int]())
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty set: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestSet_MarshalJSONConcurrent(t *testing.T) {
+	s := New[int]()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s.Add(i)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			t.Fatalf("Marshal(): unexpected error %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestSet_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string      `json:"name"`
+		Tags Set[string] `json:"tags"`
+	}
+	var c config
+	c.Name = "app"
+	c.Tags.AddMany("a")
+
+	// A field held by value is encoded through a pointer to its struct
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","tags":["a"]}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// Map values are not addressable, so only the default encoding applies
+	data, _ = json.Marshal(map[string]config{"x": {Name: "app"}})
+	if want := `{"x":{"name":"app","tags":{}}}`; string(data) != want {
+		t.Errorf("Marshal() of a non-addressable field: expected %s, got %s", want, data)
+	}
+
+	// An embedded Set encodes as the whole struct
+	type embedded struct {
+		Set[string]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.Add("a")
+	data, _ = json.Marshal(&e)
+	if string(data) != `["a"]` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `["a"]`, data)
+	}
+}
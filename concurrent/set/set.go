@@ -1,6 +1,10 @@
 package set
 
-import "sync"
+import (
+	"iter"
+	"sync"
+	"unsafe"
+)
 
 // Set is a generic, thread-safe set implementation backed by a map[T]struct{}.
 // It stores unique elements of type T.The zero value of Set[T] is ready to use
@@ -12,6 +16,7 @@ type Set[T comparable] struct {
 	_               noCopy // prevent accidental copy after first use
 	items           map[T]struct{}
 	initialCapacity int
+	jsonCompare     func(a, b T) int // set by SortJSON
 	mu              sync.RWMutex
 }
 
@@ -33,6 +38,16 @@ func NewWithCapacity[T comparable](capacity int) *Set[T] {
 	}
 }
 
+// FromSeq creates a set holding the values of seq. Duplicates are ignored.
+// slices.Collect(s.All()) does the reverse, in no particular order.
+func FromSeq[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
 // Add inserts a value into the set. If the value already exists, it does nothing.
 // Initializes the underlying map if it is nil.
 func (s *Set[T]) Add(value T) {
@@ -110,6 +125,122 @@ func (s *Set[T]) Clear() {
 	s.items = make(map[T]struct{}, s.initialCapacity)
 }
 
+// All returns an iterator over the elements of the set. The iteration
+// order is not specified.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the set; such changes are not
+// seen by the ongoing iteration.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the elements of the set.
+func (s *Set[T]) snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]T, 0, len(s.items))
+	for v := range s.items {
+		items = append(items, v)
+	}
+	return items
+}
+
+// IsSubsetOf reports whether every element of s is also in other.
+// Either operand may be a zero-value Set; the empty set is a subset of every set.
+// Both sets are read-locked for the duration of the call.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	defer rlockPair(s, other)()
+	return isSubset(s.items, other.items)
+}
+
+// IsSupersetOf reports whether every element of other is also in s.
+// Either operand may be a zero-value Set.
+// Both sets are read-locked for the duration of the call.
+func (s *Set[T]) IsSupersetOf(other *Set[T]) bool {
+	defer rlockPair(s, other)()
+	return isSubset(other.items, s.items)
+}
+
+// Equal reports whether s and other contain exactly the same elements.
+// Either operand may be a zero-value Set.
+// Both sets are read-locked for the duration of the call.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	defer rlockPair(s, other)()
+	return len(s.items) == len(other.items) && isSubset(s.items, other.items)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+// Either operand may be a zero-value Set. The smaller of the two sets
+// is iterated. Both sets are read-locked for the duration of the call.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	defer rlockPair(s, other)()
+	small, large := s.items, other.items
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for v := range small {
+		if _, ok := large[v]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset reports whether every key of a is also a key of b.
+func isSubset[T comparable](a, b map[T]struct{}) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for v := range a {
+		if _, ok := b[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rlockPair read-locks a and b and returns a function that unlocks them.
+//
+// The locks are always taken in address order so that goroutines
+// comparing a with b and b with a at the same time cannot deadlock,
+// which they otherwise could because a pending writer blocks new
+// readers. When a and b are the same set it is locked only once, as
+// a recursive read lock can also deadlock against a pending writer.
+func rlockPair[T comparable](a, b *Set[T]) (unlock func()) {
+	if a == b {
+		a.mu.RLock()
+		return a.mu.RUnlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.mu.RLock()
+	b.mu.RLock()
+	return func() {
+		b.mu.RUnlock()
+		a.mu.RUnlock()
+	}
+}
+
+// replace makes values the elements of the set, ignoring duplicates.
+func (s *Set[T]) replace(values []T) {
+	items := make(map[T]struct{}, max(s.initialCapacity, len(values)))
+	for _, v := range values {
+		items[v] = struct{}{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = items
+}
+
 // noCopy may be added to structs which must not be copied
 // after the first use.
 //
@@ -1,6 +1,7 @@
 package set
 
 import (
+	"slices"
 	"sync"
 	"testing"
 )
@@ -246,3 +247,131 @@ func TestSet_ConcurrentResetAndClear(t *testing.T) {
 		t.Errorf("Invalid size after concurrent Reset/Clear: %d", size)
 	}
 }
+
+func TestSet_All(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+
+	got := slices.Sorted(s.All())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	var zero Set[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value set: unexpected %d", v)
+	}
+}
+
+func TestSet_FromSeq(t *testing.T) {
+	s := FromSeq(slices.Values([]int{1, 2, 2, 3}))
+	if s.Len() != 3 {
+		t.Errorf("Expected size 3, got %d", s.Len())
+	}
+	for _, v := range []int{1, 2, 3} {
+		if !s.Contains(v) {
+			t.Errorf("Expected set to contain %d", v)
+		}
+	}
+}
+
+func TestSet_AllSnapshot(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+
+	// The loop body may modify the set without deadlocking,
+	// and the iteration is not affected by the modifications.
+	var got []int
+	for v := range s.All() {
+		s.Remove(v)
+		s.Add(v * 10)
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	if s.Len() != 3 || !s.Contains(10) {
+		t.Errorf("Expected set to hold the values added during iteration")
+	}
+}
+
+func TestSet_Relations(t *testing.T) {
+	a := FromSeq(slices.Values([]int{1, 2}))
+	b := FromSeq(slices.Values([]int{1, 2, 3}))
+	c := FromSeq(slices.Values([]int{4, 5}))
+	var zero Set[int]
+
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"a.IsSubsetOf(b)", a.IsSubsetOf(b), true},
+		{"b.IsSubsetOf(a)", b.IsSubsetOf(a), false},
+		{"a.IsSubsetOf(a)", a.IsSubsetOf(a), true},
+		{"zero.IsSubsetOf(a)", zero.IsSubsetOf(a), true},
+		{"a.IsSubsetOf(zero)", a.IsSubsetOf(&zero), false},
+		{"b.IsSupersetOf(a)", b.IsSupersetOf(a), true},
+		{"a.IsSupersetOf(b)", a.IsSupersetOf(b), false},
+		{"a.IsSupersetOf(zero)", a.IsSupersetOf(&zero), true},
+		{"a.Equal(b)", a.Equal(b), false},
+		{"a.Equal(a)", a.Equal(a), true},
+		{"a.Equal(copy)", a.Equal(FromSeq(slices.Values([]int{2, 1}))), true},
+		{"zero.Equal(New)", zero.Equal(New[int]()), true},
+		{"a.IsDisjoint(c)", a.IsDisjoint(c), true},
+		{"a.IsDisjoint(b)", a.IsDisjoint(b), false},
+		{"a.IsDisjoint(zero)", a.IsDisjoint(&zero), true},
+		{"zero.IsDisjoint(zero)", zero.IsDisjoint(&zero), true},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.expected)
+		}
+	}
+}
+
+func TestSet_ConcurrentRelations(t *testing.T) {
+	const goroutines = 8
+	const iterations = 2000
+
+	a := FromSeq(slices.Values([]int{1, 2, 3}))
+	b := FromSeq(slices.Values([]int{1, 2, 3, 4}))
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 3)
+	for g := 0; g < goroutines; g++ {
+		// Compare in both lock orders and with self at the same time
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				a.IsSubsetOf(b)
+				a.Equal(b)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				b.IsSupersetOf(a)
+				b.IsDisjoint(a)
+				b.Equal(b)
+			}
+		}()
+		// Writers keep a lock request pending, which blocks new readers
+		// and turns an inconsistent lock order into a deadlock.
+		go func(v int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				a.Add(v + 10)
+				b.Remove(v + 10)
+				a.Remove(v + 10)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if !a.IsSubsetOf(b) {
+		t.Errorf("Expected a to be a subset of b after concurrent use")
+	}
+}
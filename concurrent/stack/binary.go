@@ -0,0 +1,38 @@
+package stack
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The items are written
+// from bottom to top in a compact versioned format, from a snapshot
+// taken under the read lock.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// Because Stack must not be copied, only a *Stack implements
+// encoding.BinaryMarshaler and gob.GobEncoder.
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, s.snapshot())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the stack with data encoded by MarshalBinary. On error the
+// stack is not modified. A bounded stack keeps only the top MaxDepth
+// items, evicting the others.
+// It returns ErrClosed without modifying anything if the stack is closed.
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	return s.replace(items)
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (s *Stack[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (s *Stack[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
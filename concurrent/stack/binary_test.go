@@ -0,0 +1,61 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+func TestStack_Binary(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+	decoded := New[int]()
+	decoded.Push(9)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.Backward()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("UnmarshalBinary(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	// Invalid data leaves the stack untouched
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("UnmarshalBinary() of truncated data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Len(): expected %d, got %d", 3, decoded.Len())
+	}
+}
+
+func TestStack_Gob(t *testing.T) {
+	type checkpoint struct {
+		Name  string
+		Items *Stack[string]
+	}
+	in := checkpoint{Name: "cp", Items: New[string]()}
+	in.Items.PushMany("a", "b", "c")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode(): unexpected error %v", err)
+	}
+	var out checkpoint
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode(): unexpected error %v", err)
+	}
+	if out.Name != "cp" {
+		t.Errorf("Decode(): expected name %q, got %q", "cp", out.Name)
+	}
+	if got := slices.Collect(out.Items.Backward()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Decode(): expected %v, got %v", []string{"a", "b", "c"}, got)
+	}
+}
@@ -0,0 +1,39 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The stack is encoded as a JSON
+// array holding its items from bottom to top, the order in which they were
+// pushed, so the last element is the top. The items are copied under the
+// read lock, so the encoding is a consistent snapshot even while other
+// goroutines modify the stack. An empty stack encodes as [].
+//
+// Because Stack must not be copied, only a *Stack implements json.Marshaler.
+// A struct holding a Stack by value must be encoded through a pointer, as
+// in json.Marshal(&v): json.Marshal(v) copies the lock, which go vet
+// reports, and encodes the stack as {}. Embedding a Stack promotes
+// MarshalJSON to the embedding struct, which then encodes as the stack
+// alone; use a named field to keep the other fields.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.snapshot())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the stack with the items of a JSON array, pushed in order so that the
+// last element ends up on top. As is conventional, a JSON null leaves the
+// stack unchanged. A bounded stack keeps only the top MaxDepth items,
+// evicting the others.
+// Returns ErrClosed without modifying anything if the stack is closed.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	return s.replace(items)
+}
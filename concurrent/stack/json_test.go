@@ -0,0 +1,80 @@
+package stack
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestStack_JSON(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Marshal(): expected %s, got %s", "[1,2,3]", data)
+	}
+
+	decoded := New[int]()
+	decoded.Push(9)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	for _, val := range []int{3, 2, 1} {
+		r, ok := decoded.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+
+	data, _ = json.Marshal(New[int]())
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty stack: expected %s, got %s", "[]", data)
+	}
+
+	decoded.Close()
+	if err := json.Unmarshal([]byte("[1]"), decoded); !errors.Is(err, ErrClosed) {
+		t.Errorf("Unmarshal() after Close(): expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestStack_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string        `json:"name"`
+		Jobs Stack[string] `json:"jobs"`
+	}
+	var c config
+	c.Name = "app"
+	c.Jobs.Push("a")
+
+	// A field held by value is encoded through a pointer to its struct
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","jobs":["a"]}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// Map values are not addressable, so only the default encoding applies
+	data, _ = json.Marshal(map[string]config{"x": {Name: "app"}})
+	if want := `{"x":{"name":"app","jobs":{}}}`; string(data) != want {
+		t.Errorf("Marshal() of a non-addressable field: expected %s, got %s", want, data)
+	}
+
+	// An embedded Stack encodes as the whole struct
+	type embedded struct {
+		Stack[string]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.Push("a")
+	data, _ = json.Marshal(&e)
+	if string(data) != `["a"]` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `["a"]`, data)
+	}
+}
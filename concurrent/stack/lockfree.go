@@ -0,0 +1,235 @@
+package stack
+
+import (
+	"iter"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync/atomic"
+)
+
+// eliminationSpins is the number of times a push offered for elimination
+// yields the processor while it waits for a pop to take it.
+const eliminationSpins = 8
+
+// LockFree is a generic, lock-free LIFO (last-in-first-out) stack that is
+// safe for concurrent use by multiple goroutines. It is a Treiber stack:
+// a linked list of immutable nodes whose top is swapped with a single
+// compare-and-swap, so no goroutine ever waits for a lock and a stalled
+// goroutine cannot block the others. Popped nodes are never reused; the
+// garbage collector keeps a node alive while any goroutine still holds
+// it, which rules out the ABA problem.
+// The zero value of LockFree[T] is ready to use without initialization.
+//
+// Use NewLockFreeWithElimination() to add an elimination array: under
+// heavy contention, a push and a pop that both fail their compare-and-swap
+// can meet in the array and exchange the item directly, without touching
+// the top of the stack.
+//
+// Unlike Stack, every push allocates a node and LockFree cannot be closed.
+// Prefer Stack for bulk operations or when contention is low.
+type LockFree[T any] struct {
+	_    noCopy // prevent accidental copy after first use
+	top  atomic.Pointer[node[T]]
+	elim []eliminationSlot[T] // nil disables elimination
+}
+
+// node is an element of a LockFree stack. It is not modified once it is
+// reachable from the top of the stack.
+type node[T any] struct {
+	item T
+	next *node[T]
+	size int // number of nodes from this one to the bottom, inclusive
+}
+
+// eliminationSlot holds a node offered by a push until a pop takes it
+// or the push withdraws it. It is padded to a cache line so that
+// neighbouring slots do not contend.
+type eliminationSlot[T any] struct {
+	offer atomic.Pointer[node[T]]
+	_     [56]byte
+}
+
+// NewLockFree creates an empty lock-free stack of type T.
+// This is equivalent to creating a stack as `var s stack.LockFree[int]`
+func NewLockFree[T any]() *LockFree[T] {
+	return &LockFree[T]{}
+}
+
+// NewLockFreeWithElimination creates an empty lock-free stack of type T
+// with an elimination array of the given width. A non-positive width
+// selects GOMAXPROCS. Wider arrays help with more contending goroutines,
+// but make it less likely that a push and a pop meet.
+func NewLockFreeWithElimination[T any](width int) *LockFree[T] {
+	if width <= 0 {
+		width = runtime.GOMAXPROCS(0)
+	}
+	return &LockFree[T]{
+		elim: make([]eliminationSlot[T], width),
+	}
+}
+
+// Push adds an item to the top of the stack.
+func (s *LockFree[T]) Push(item T) {
+	n := &node[T]{item: item}
+	for {
+		top := s.top.Load()
+		n.next = top
+		n.size = top.len() + 1
+		if s.top.CompareAndSwap(top, n) {
+			return
+		}
+		if s.eliminatePush(n) {
+			return
+		}
+	}
+}
+
+// PushMany pushes one or more items onto the stack in order, so the last
+// item ends up on top. All of them are added with a single compare-and-swap,
+// so no other item is pushed in between.
+func (s *LockFree[T]) PushMany(item ...T) {
+	if len(item) == 0 {
+		return
+	}
+	bottom := &node[T]{item: item[0]}
+	first := bottom
+	for _, v := range item[1:] {
+		first = &node[T]{item: v, next: first}
+	}
+	for {
+		top := s.top.Load()
+		bottom.next = top
+		base := top.len()
+		for n, size := first, base+len(item); size > base; n, size = n.next, size-1 {
+			n.size = size
+		}
+		if s.top.CompareAndSwap(top, first) {
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top element of the stack.
+// The boolean return is false if the stack is empty.
+func (s *LockFree[T]) Pop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			return top.item, true
+		}
+		if n, ok := s.eliminatePop(); ok {
+			return n.item, true
+		}
+	}
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean return is false if the stack is empty.
+func (s *LockFree[T]) Peek() (T, bool) {
+	top := s.top.Load()
+	if top == nil {
+		var zero T
+		return zero, false
+	}
+	return top.item, true
+}
+
+// Len returns the current number of items in the stack.
+func (s *LockFree[T]) Len() int {
+	return s.top.Load().len()
+}
+
+// Clear removes all items from the stack.
+func (s *LockFree[T]) Clear() {
+	s.top.Store(nil)
+}
+
+// All returns an iterator over the items of the stack from top to
+// bottom, the order in which Pop would return them, without removing them.
+//
+// Nodes are immutable, so the iterator walks the stack as it was when
+// iteration started without copying it. The loop body may modify the
+// stack; such changes are not seen by the ongoing iteration.
+func (s *LockFree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.top.Load(); n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from bottom
+// to top, the order in which they were pushed, without removing them.
+//
+// Like All, it sees the stack as it was when iteration started, but it
+// copies the items first since the nodes are linked from the top.
+func (s *LockFree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(s.snapshot()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the items of the stack from top to bottom.
+func (s *LockFree[T]) snapshot() []T {
+	top := s.top.Load()
+	items := make([]T, 0, top.len())
+	for n := top; n != nil; n = n.next {
+		items = append(items, n.item)
+	}
+	return items
+}
+
+// eliminatePush offers n in a random slot of the elimination array and
+// waits briefly for a pop to take it. It reports whether a pop took n;
+// if not, n has been withdrawn and the caller retries on the stack.
+func (s *LockFree[T]) eliminatePush(n *node[T]) bool {
+	if s.elim == nil {
+		return false
+	}
+	slot := &s.elim[rand.IntN(len(s.elim))].offer
+	if !slot.CompareAndSwap(nil, n) {
+		return false // the slot is taken by another push
+	}
+	for range eliminationSpins {
+		if slot.Load() != n {
+			return true
+		}
+		runtime.Gosched()
+	}
+	// Withdraw the offer; failing to do so means a pop has just taken it
+	return !slot.CompareAndSwap(n, nil)
+}
+
+// eliminatePop takes the node offered in a random slot of the elimination
+// array, if any. The boolean return is false if there was none.
+func (s *LockFree[T]) eliminatePop() (*node[T], bool) {
+	if s.elim == nil {
+		return nil, false
+	}
+	slot := &s.elim[rand.IntN(len(s.elim))].offer
+	n := slot.Load()
+	if n == nil || !slot.CompareAndSwap(n, nil) {
+		return nil, false
+	}
+	return n, true
+}
+
+// len returns the number of nodes from n to the bottom of the stack.
+// It is 0 for a nil node, the top of an empty stack.
+func (n *node[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
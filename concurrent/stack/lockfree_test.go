@@ -0,0 +1,210 @@
+package stack
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFree_PushPop(t *testing.T) {
+	s := NewLockFree[int]()
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Pop() on empty stack: expected NOK, got OK")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Errorf("Peek() on empty stack: expected NOK, got OK")
+	}
+	s.Push(1)
+	s.Push(2)
+	if s.Len() != 2 {
+		t.Errorf("Len(): expected %d, got %d", 2, s.Len())
+	}
+	if r, ok := s.Peek(); !ok || r != 2 {
+		t.Errorf("Peek(): expected %d, got %d", 2, r)
+	}
+	for _, val := range []int{2, 1} {
+		if r, ok := s.Pop(); !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, s.Len())
+	}
+
+	var zero LockFree[int] // zero-value
+	zero.Push(1)
+	if r, ok := zero.Pop(); !ok || r != 1 {
+		t.Errorf("Pop() on zero value: expected %d, got %d", 1, r)
+	}
+}
+
+func TestLockFree_PushMany(t *testing.T) {
+	s := NewLockFree[int]()
+	s.PushMany()
+	if s.Len() != 0 {
+		t.Errorf("Len() after PushMany(): expected %d, got %d", 0, s.Len())
+	}
+	s.Push(0)
+	s.PushMany(1, 2, 3)
+	if s.Len() != 4 {
+		t.Errorf("Len(): expected %d, got %d", 4, s.Len())
+	}
+	for _, val := range []int{3, 2, 1, 0} {
+		if r, ok := s.Pop(); !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+		if s.Len() != val {
+			t.Errorf("Len(): expected %d, got %d", val, s.Len())
+		}
+	}
+}
+
+func TestLockFree_Clear(t *testing.T) {
+	s := NewLockFree[int]()
+	s.PushMany(1, 2, 3)
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear(): expected %d, got %d", 0, s.Len())
+	}
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Pop() after Clear(): expected NOK, got OK")
+	}
+}
+
+func TestLockFree_All(t *testing.T) {
+	s := NewLockFree[int]()
+	s.PushMany(1, 2, 3)
+
+	got := slices.Collect(s.All())
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("All(): expected %v, got %v", []int{3, 2, 1}, got)
+	}
+	got = slices.Collect(s.Backward())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	// The iteration sees the stack as it was when it started
+	got = got[:0]
+	for v := range s.All() {
+		s.Pop()
+		s.Push(10)
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("All() while modifying: expected %v, got %v", []int{3, 2, 1}, got)
+	}
+
+	var zero LockFree[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value stack: unexpected %d", v)
+	}
+}
+
+func TestLockFree_Elimination(t *testing.T) {
+	s := NewLockFreeWithElimination[int](1)
+	if len(s.elim) != 1 {
+		t.Fatalf("Expected an elimination array of width %d, got %d", 1, len(s.elim))
+	}
+	if _, ok := s.eliminatePop(); ok {
+		t.Errorf("eliminatePop() without an offer: expected NOK, got OK")
+	}
+
+	// A push that finds no pop withdraws its offer
+	n := &node[int]{item: 1}
+	if s.eliminatePush(n) {
+		t.Errorf("eliminatePush() without a pop: expected false, got true")
+	}
+	if s.elim[0].offer.Load() != nil {
+		t.Errorf("eliminatePush() did not withdraw its offer")
+	}
+
+	// A pop takes an offered push
+	s.elim[0].offer.Store(n)
+	if m, ok := s.eliminatePop(); !ok || m.item != 1 {
+		t.Errorf("eliminatePop(): expected %d, got %v", 1, m)
+	}
+
+	// Concurrent pushes and pops pair up through the array
+	done := make(chan bool)
+	go func() { done <- s.eliminatePush(&node[int]{item: 2}) }()
+	for {
+		if m, ok := s.eliminatePop(); ok {
+			if m.item != 2 {
+				t.Errorf("eliminatePop(): expected %d, got %d", 2, m.item)
+			}
+			if !<-done {
+				t.Errorf("eliminatePush(): expected true after a pop took the offer")
+			}
+			break
+		}
+		select {
+		case <-done:
+			// The offer was withdrawn before the pop saw it; try again
+			go func() { done <- s.eliminatePush(&node[int]{item: 2}) }()
+		default:
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, s.Len())
+	}
+
+	if NewLockFreeWithElimination[int](0).elim == nil {
+		t.Errorf("NewLockFreeWithElimination(0): expected an elimination array")
+	}
+}
+
+func TestLockFree_Concurrent(t *testing.T) {
+	for name, s := range map[string]*LockFree[int]{
+		"Plain":       NewLockFree[int](),
+		"Elimination": NewLockFreeWithElimination[int](2),
+	} {
+		t.Run(name, func(t *testing.T) {
+			const goroutines = 8
+			const opsPerGoroutine = 2000
+			var wg sync.WaitGroup
+			popped := make([][]int, goroutines)
+			for g := 0; g < goroutines; g++ {
+				wg.Add(2)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < opsPerGoroutine; i++ {
+						if i%10 == 0 {
+							s.PushMany(g*opsPerGoroutine + i)
+						} else {
+							s.Push(g*opsPerGoroutine + i)
+						}
+					}
+				}(g)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < opsPerGoroutine; i++ {
+						if v, ok := s.Pop(); ok {
+							popped[g] = append(popped[g], v)
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+			for v, ok := s.Pop(); ok; v, ok = s.Pop() {
+				popped[0] = append(popped[0], v)
+			}
+
+			// Every item is popped exactly once
+			count := make([]int, goroutines*opsPerGoroutine)
+			for _, vals := range popped {
+				for _, v := range vals {
+					count[v]++
+				}
+			}
+			for v, n := range count {
+				if n != 1 {
+					t.Fatalf("Item %d popped %d times", v, n)
+				}
+			}
+			if s.Len() != 0 {
+				t.Errorf("Expected empty stack, got Len() = %d", s.Len())
+			}
+		})
+	}
+}
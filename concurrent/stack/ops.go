@@ -0,0 +1,134 @@
+package stack
+
+// The operations in this file are named after the Forth words that
+// manipulate the data stack. Positions are counted from the top: the top
+// element is at 0 and the one below it at 1. Each operation holds the
+// lock for its whole duration, so it is atomic with respect to the other
+// methods, and reports false, leaving the stack unchanged, if the stack
+// holds too few elements.
+//
+// Operations that add an element also return ErrClosed, like Push, if
+// the stack is closed. The others rearrange or remove elements, which is
+// allowed on a closed stack, as popping is.
+
+// Dup pushes a copy of the top element: ( a -- a a ).
+func (s *Stack[T]) Dup() (bool, error) {
+	return s.Pick(0)
+}
+
+// Over pushes a copy of the element below the top: ( a b -- a b a ).
+func (s *Stack[T]) Over() (bool, error) {
+	return s.Pick(1)
+}
+
+// Pick pushes a copy of the element at position n from the top:
+// ( xn ... x0 -- xn ... x0 xn ). Pick(0) is Dup and Pick(1) is Over.
+// It returns ErrClosed without adding anything if the stack is closed.
+func (s *Stack[T]) Pick(n int) (bool, error) {
+	var evicted []T
+	defer s.notify(&evicted)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false, ErrClosed
+	}
+	if n < 0 || n >= s.count {
+		return false, nil
+	}
+	s.push(s.items[s.at(n)], &evicted)
+	return true, nil
+}
+
+// Swap exchanges the top two elements: ( a b -- b a ).
+func (s *Stack[T]) Swap() bool {
+	return s.Roll(1)
+}
+
+// Rot moves the third element from the top to the top: ( a b c -- b c a ).
+func (s *Stack[T]) Rot() bool {
+	return s.Roll(2)
+}
+
+// Roll moves the element at position n from the top to the top, shifting
+// the elements above it down by one: ( xn ... x0 -- xn-1 ... x0 xn ).
+// Roll(1) is Swap and Roll(2) is Rot. It runs in O(n) time.
+func (s *Stack[T]) Roll(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 0 || n >= s.count {
+		return false
+	}
+	item := s.items[s.at(n)]
+	for i := n; i > 0; i-- {
+		s.items[s.at(i)] = s.items[s.at(i-1)]
+	}
+	s.items[s.at(0)] = item
+	return true
+}
+
+// Drop removes the top n elements: ( xn-1 ... x0 -- ).
+// The stack may shrink its capacity automatically as with Pop.
+func (s *Stack[T]) Drop(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 0 || n > s.count {
+		return false
+	}
+	s.truncate(s.count - n)
+	return true
+}
+
+// PeekN returns a copy of the top n elements without removing them,
+// in the order they were pushed, so the top element comes last.
+// The boolean return is false if the stack holds fewer than n elements.
+func (s *Stack[T]) PeekN(n int) ([]T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.peekN(n)
+}
+
+// PopN removes the top n elements and returns them in the order they
+// were pushed, so the top element comes last. The boolean return is
+// false, leaving the stack unchanged, if it holds fewer than n elements.
+// The stack may shrink its capacity automatically as with Pop.
+func (s *Stack[T]) PopN(n int) ([]T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, ok := s.peekN(n)
+	if ok {
+		s.truncate(s.count - n)
+	}
+	return items, ok
+}
+
+// peekN returns a copy of the top n elements, bottom first.
+// It must be called with s.mu held.
+func (s *Stack[T]) peekN(n int) ([]T, bool) {
+	if n < 0 || n > s.count {
+		return nil, false
+	}
+	items := make([]T, n)
+	if n > 0 {
+		start := s.index(s.count - n)
+		m := copy(items, s.items[start:min(start+n, len(s.items))])
+		copy(items[m:], s.items[:n-m])
+	}
+	return items, true
+}
+
+// at returns the position in the ring buffer of the element at
+// position n from the top.
+func (s *Stack[T]) at(n int) int {
+	return s.index(s.count - 1 - n)
+}
+
+// truncate removes the elements above depth and shrinks the buffer when
+// appropriate. It must be called with s.mu held.
+func (s *Stack[T]) truncate(depth int) {
+	var zero T
+	for s.count > depth {
+		s.count--
+		s.items[s.index(s.count)] = zero // release the reference for the GC
+	}
+	s.shrink()
+}
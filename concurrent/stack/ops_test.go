@@ -0,0 +1,136 @@
+package stack
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// stackOf returns a stack holding items, the last one on top, wrapped
+// around the end of its ring buffer so that the operations cross it.
+func stackOf(items ...int) *Stack[int] {
+	s := NewWithCapacity[int](len(items) + 2)
+	s.head = 3 // start near the end of the buffer
+	s.PushMany(items...)
+	return s
+}
+
+func TestStack_Ops(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s *Stack[int]) bool
+		want []int
+	}{
+		{"Dup", func(s *Stack[int]) bool { ok, _ := s.Dup(); return ok }, []int{1, 2, 3, 3}},
+		{"Over", func(s *Stack[int]) bool { ok, _ := s.Over(); return ok }, []int{1, 2, 3, 2}},
+		{"Swap", (*Stack[int]).Swap, []int{1, 3, 2}},
+		{"Rot", (*Stack[int]).Rot, []int{2, 3, 1}},
+		{"Pick(2)", func(s *Stack[int]) bool { ok, _ := s.Pick(2); return ok }, []int{1, 2, 3, 1}},
+		{"Roll(0)", func(s *Stack[int]) bool { return s.Roll(0) }, []int{1, 2, 3}},
+		{"Drop(2)", func(s *Stack[int]) bool { return s.Drop(2) }, []int{1}},
+		{"Drop(0)", func(s *Stack[int]) bool { return s.Drop(0) }, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		s := stackOf(1, 2, 3)
+		if !tt.op(s) {
+			t.Errorf("%s: expected success", tt.name)
+		}
+		if got := slices.Collect(s.Backward()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	s := stackOf(1, 2, 3, 4, 5)
+	s.Roll(4)
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{2, 3, 4, 5, 1}) {
+		t.Errorf("Roll(4): expected %v, got %v", []int{2, 3, 4, 5, 1}, got)
+	}
+}
+
+func TestStack_OpsUnderflow(t *testing.T) {
+	s := New[int]()
+	if ok, err := s.Dup(); ok || err != nil {
+		t.Errorf("Dup() on empty stack: expected false and no error, got %v and %v", ok, err)
+	}
+	s.Push(1)
+	if ok, err := s.Over(); ok || err != nil {
+		t.Errorf("Over() on 1 element: expected false and no error, got %v and %v", ok, err)
+	}
+	if ok, err := s.Pick(-1); ok || err != nil {
+		t.Errorf("Pick(-1): expected false and no error, got %v and %v", ok, err)
+	}
+	if s.Swap() || s.Rot() || s.Roll(1) || s.Drop(2) || s.Drop(-1) {
+		t.Errorf("Swap(), Rot(), Roll(1) or Drop(2) on 1 element: expected false, got true")
+	}
+	if _, ok := s.PeekN(2); ok {
+		t.Errorf("PeekN(2) on 1 element: expected NOK, got OK")
+	}
+	if _, ok := s.PopN(2); ok {
+		t.Errorf("PopN(2) on 1 element: expected NOK, got OK")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() after failed operations: expected %d, got %d", 1, s.Len())
+	}
+
+	// A closed stack refuses new elements but can be rearranged and drained
+	s.Push(2)
+	s.Close()
+	if ok, err := s.Dup(); ok || !errors.Is(err, ErrClosed) {
+		t.Errorf("Dup() on closed stack: expected false and %v, got %v and %v", ErrClosed, ok, err)
+	}
+	if !s.Swap() {
+		t.Errorf("Swap() on closed stack: expected true, got false")
+	}
+	if got, ok := s.PopN(2); !ok || !slices.Equal(got, []int{2, 1}) {
+		t.Errorf("PopN(2) on closed stack: expected %v, got %v", []int{2, 1}, got)
+	}
+}
+
+func TestStack_PeekNPopN(t *testing.T) {
+	s := stackOf(1, 2, 3, 4)
+	if got, ok := s.PeekN(3); !ok || !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PeekN(3): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+	if got, ok := s.PeekN(0); !ok || len(got) != 0 {
+		t.Errorf("PeekN(0): expected an empty slice, got %v", got)
+	}
+	if got, ok := s.PopN(3); !ok || !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PopN(3): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1}) {
+		t.Errorf("Backward() after PopN(): expected %v, got %v", []int{1}, got)
+	}
+	for i := range s.items {
+		if i != s.head && s.items[i] != 0 {
+			t.Errorf("Slot %d still holds a popped element", i)
+		}
+	}
+}
+
+func TestStack_ConcurrentOps(t *testing.T) {
+	// Every goroutine pushes a pair and rearranges it atomically, so the
+	// stack always holds whole pairs, whatever the interleaving
+	s := New[int]()
+	const goroutines = 8
+	const opsPerGoroutine = 500
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < opsPerGoroutine; i++ {
+				s.PushMany(g, -g)
+				s.Swap()
+				if pair, ok := s.PopN(2); ok && pair[0] != -pair[1] {
+					t.Errorf("PopN(2): expected a pair, got %v", pair)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if s.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, s.Len())
+	}
+}
@@ -1,22 +1,41 @@
 package stack
 
-import "sync"
+import (
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+)
 
 // Stack is a generic, thread-safe LIFO (last-in-first-out) stack
-// implementation backed by a dynamically resizing slice.The zero value
-// of Stack[T] is ready to use without initialization.
+// implementation backed by a dynamically resizing ring buffer. The zero
+// value of Stack[T] is ready to use without initialization.
 //
 // Use New() or NewWithCapacity() if you prefer an explicit constructor
-// or want to set an initial capacity.
+// or want to set an initial capacity. Use NewBounded() to limit the depth
+// of the stack: a push onto a full bounded stack drops the bottom element.
 // All operations on Stack are safe for concurrent use by multiple goroutines.
 // If you do not need thread-safety, use the collections/stack package instead for better performance.
+//
+// Call Close() to shut the stack down: pushes then fail with ErrClosed,
+// while consumers can still drain the remaining items. Close only stops
+// producers: unlike collections/concurrent/queue, Stack has no blocking
+// pop and no end-of-stream error, so consumers poll Pop and check Closed.
 type Stack[T any] struct {
 	_               noCopy // prevent accidental copy after first use
-	items           []T
+	items           []T    // ring buffer; len(items) is the capacity
+	head            int    // index of the bottom element
+	count           int    // number of elements in the stack
 	initialCapacity int
+	maxDepth        int // maximum number of elements; 0 means unbounded
+	onEvict         func(item T)
+	closed          bool
 	mu              sync.RWMutex
 }
 
+// ErrClosed is returned by push operations on a stack that has been closed.
+var ErrClosed = errors.New("stack: closed")
+
 // shrinkCapacityThreshold defines the minimum slice capacity before
 // shrink operations are considered. Avoids aggressive shrinking for
 // small stacks that would just grow again.
@@ -39,25 +58,95 @@ func New[T any]() *Stack[T] {
 // many elements you’ll push.
 func NewWithCapacity[T any](capacity int) *Stack[T] {
 	return &Stack[T]{
-		items:           make([]T, 0, capacity),
+		items:           make([]T, capacity),
 		initialCapacity: capacity,
 	}
 }
 
+// NewBounded creates an empty stack of type T that holds at most maxDepth
+// items. Pushing onto a full stack drops the bottom (oldest) element in
+// O(1) time to make room. A maxDepth of zero or less means the stack is
+// unbounded.
+func NewBounded[T any](maxDepth int) *Stack[T] {
+	return NewBoundedWithEvict[T](maxDepth, nil)
+}
+
+// NewBoundedWithEvict creates an empty stack of type T that holds at most
+// maxDepth items and calls onEvict with every element it drops to stay
+// within that depth, oldest first. onEvict runs after the stack's lock has
+// been released, so it may call methods of the stack. It is not called for
+// elements removed by Pop, Reset or Clear.
+func NewBoundedWithEvict[T any](maxDepth int, onEvict func(item T)) *Stack[T] {
+	return &Stack[T]{
+		items:           []T{},
+		initialCapacity: 0,
+		maxDepth:        max(maxDepth, 0),
+		onEvict:         onEvict,
+	}
+}
+
+// FromSeq creates a stack by pushing the values of seq in the order
+// they are produced, so the last value yielded ends up on top.
+// slices.Collect(s.Backward()) does the reverse, and
+// FromSeq(slices.Values(items)) rebuilds the same stack from its result.
+func FromSeq[T any](seq iter.Seq[T]) *Stack[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Push(v)
+	}
+	return s
+}
+
 // PushMany pushes one or more items onto the stack in order.
 // Equivalent to calling Push repeatedly but more efficient
-// when adding multiple elements.
-func (s *Stack[T]) PushMany(item ...T) {
+// when adding multiple elements. On a bounded stack, the bottom
+// elements are dropped as needed, including the first of item
+// if there are more than the maximum depth.
+// Returns ErrClosed without adding anything if the stack is closed.
+func (s *Stack[T]) PushMany(item ...T) error {
+	var evicted []T
+	defer s.notify(&evicted)
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.items = append(s.items, item...)
+	if s.closed {
+		return ErrClosed
+	}
+	if s.maxDepth > 0 {
+		if drop := s.count + len(item) - s.maxDepth; drop > 0 {
+			for range min(drop, s.count) {
+				s.evict(&evicted)
+			}
+			if over := len(item) - s.maxDepth; over > 0 {
+				if s.onEvict != nil {
+					evicted = append(evicted, item[:over]...)
+				}
+				item = item[over:]
+			}
+		}
+	}
+	if s.count+len(item) > len(s.items) {
+		s.grow(len(item))
+	}
+	top := s.index(s.count)
+	n := copy(s.items[top:], item)
+	copy(s.items, item[n:])
+	s.count += len(item)
+	return nil
 }
 
-// Push adds a single item to the top of the stack.
-func (s *Stack[T]) Push(item T) {
+// Push adds a single item to the top of the stack. On a full bounded
+// stack, the bottom element is dropped first.
+// Returns ErrClosed without adding the item if the stack is closed.
+func (s *Stack[T]) Push(item T) error {
+	var evicted []T
+	defer s.notify(&evicted)
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.items = append(s.items, item)
+	if s.closed {
+		return ErrClosed
+	}
+	s.push(item, &evicted)
+	return nil
 }
 
 // Pop removes and returns the top element of the stack.
@@ -67,42 +156,15 @@ func (s *Stack[T]) Push(item T) {
 func (s *Stack[T]) Pop() (T, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if len(s.items) == 0 {
-		var zero T
+	var zero T
+	if s.count == 0 {
 		return zero, false
 	}
-	item := s.items[len(s.items)-1]
-	s.items = s.items[:len(s.items)-1]
-
-	// Reduce capacity if:
-	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
-	//   - current capacity exceeds 2× the initial capacity (if any),
-	//   - and fewer than 12.5% of elements are in use (cap/8).
-	//
-	// Why 1/8 instead of 1/4?
-	//   Using 1/4 is fine for general use, but in tight push/pop workloads
-	//   it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
-	//   only when the queue is significantly underutilized.
-	//
-	// Why halve capacity?
-	//   Halving avoids repeated reallocations while still reclaiming
-	//   unused memory proportionally. It balances memory efficiency and speed.
-	capNow := cap(s.items)
-	if capNow > shrinkCapacityThreshold &&
-		(s.initialCapacity == 0 || capNow > s.initialCapacity*2) &&
-		len(s.items) < capNow/8 {
-
-		newCap := capNow / 2
-		if s.initialCapacity > 0 && newCap < s.initialCapacity {
-			newCap = s.initialCapacity
-		}
-		if newCap != capNow { // only shrink if capacity actually changes
-			newItems := make([]T, len(s.items), newCap)
-			copy(newItems, s.items)
-			s.items = newItems
-		}
-	}
-
+	top := s.index(s.count - 1)
+	item := s.items[top]
+	s.items[top] = zero // release the reference for the GC
+	s.count--
+	s.shrink()
 	return item, true
 }
 
@@ -111,36 +173,238 @@ func (s *Stack[T]) Pop() (T, bool) {
 func (s *Stack[T]) Peek() (T, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if len(s.items) == 0 {
+	if s.count == 0 {
 		var zero T
 		return zero, false
 	}
-	return s.items[len(s.items)-1], true
+	return s.items[s.index(s.count-1)], true
 }
 
 // Len returns the current number of items in the stack.
 func (s *Stack[T]) Len() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.items)
+	return s.count
+}
+
+// MaxDepth returns the maximum number of items the stack holds,
+// or 0 if it is unbounded.
+func (s *Stack[T]) MaxDepth() int {
+	return s.maxDepth
 }
 
 // Reset clears all items but keeps the current capacity
-// of the underlying slice. This is faster than Clear()
+// of the underlying buffer. This is faster than Clear()
 // when you expect to reuse the same stack size.
 func (s *Stack[T]) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.items = s.items[:0]
+	clear(s.items)
+	s.head = 0
+	s.count = 0
 }
 
-// Clear removes all items and reallocates a slice with
+// Clear removes all items and reallocates a buffer with
 // the initial capacity (if any). Use this to shrink the
 // backing array explicitly.
 func (s *Stack[T]) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.items = make([]T, 0, s.initialCapacity)
+	s.items = make([]T, s.initialCapacity)
+	s.head = 0
+	s.count = 0
+}
+
+// Close shuts the stack down. Subsequent pushes fail with ErrClosed.
+// Close only stops producers: it does not wake up or notify consumers,
+// and items already on the stack stay available to Pop. Pop reports
+// false both on an empty open stack and on a drained closed one, so a
+// consumer that needs to detect the end of the stream calls Closed
+// before Pop: if Closed reported true and Pop then reports false, the
+// stack is drained and no item will be pushed again.
+// Calling Close more than once has no further effect.
+func (s *Stack[T]) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+// Closed reports whether Close has been called on the stack.
+func (s *Stack[T]) Closed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.closed
+}
+
+// All returns an iterator over the items of the stack from top to
+// bottom, the order in which Pop would return them, without removing them.
+//
+// The iterator runs over a snapshot taken under the read lock when
+// iteration starts, so the lock is not held while the loop body runs.
+// The loop body may therefore modify the stack; such changes are not
+// seen by the ongoing iteration.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(s.snapshot()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from bottom
+// to top, the order in which they were pushed, without removing them.
+//
+// Like All, it runs over a snapshot and does not hold the lock while
+// the loop body runs.
+func (s *Stack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of the items of the stack from bottom to top.
+func (s *Stack[T]) snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]T, s.count)
+	if s.count > 0 {
+		n := copy(items, s.items[s.head:min(s.head+s.count, len(s.items))])
+		copy(items[n:], s.items[:s.count-n])
+	}
+	return items
+}
+
+// replace makes items the contents of the stack, from bottom to top.
+// The buffer keeps at least the initial capacity. On a bounded stack,
+// only the top items are kept and the others are evicted, oldest first.
+// It returns ErrClosed without modifying anything if the stack is closed.
+func (s *Stack[T]) replace(items []T) error {
+	var evicted []T
+	defer s.notify(&evicted)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if s.maxDepth > 0 && len(items) > s.maxDepth {
+		over := len(items) - s.maxDepth
+		if s.onEvict != nil {
+			evicted = items[:over]
+		}
+		items = slices.Clone(items[over:]) // do not retain the evicted items
+	}
+	if len(items) < s.initialCapacity {
+		items = append(make([]T, 0, s.initialCapacity), items...)
+	}
+	s.items = items[:cap(items)]
+	s.head = 0
+	s.count = len(items)
+	return nil
+}
+
+// index returns the position in the ring buffer of the element
+// that is offset places above the bottom of the stack.
+func (s *Stack[T]) index(offset int) int {
+	i := s.head + offset
+	if i >= len(s.items) {
+		i -= len(s.items)
+	}
+	return i
+}
+
+// grow makes room for at least n more elements by doubling the
+// capacity, or more if a single PushMany needs it. A bounded stack
+// does not grow past its maximum depth.
+func (s *Stack[T]) grow(n int) {
+	newCap := max(len(s.items)*2, s.count+n)
+	if s.maxDepth > 0 {
+		newCap = min(newCap, max(s.maxDepth, s.count+n))
+	}
+	s.resize(newCap)
+}
+
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the bottom of the stack is at index 0.
+func (s *Stack[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if s.count > 0 {
+		n := copy(newItems, s.items[s.head:min(s.head+s.count, len(s.items))])
+		copy(newItems[n:], s.items[:s.count-n])
+	}
+	s.items = newItems
+	s.head = 0
+}
+
+// push adds item to the top of the stack, evicting the bottom element
+// first if the stack is bounded and full. It must be called with s.mu held.
+func (s *Stack[T]) push(item T, evicted *[]T) {
+	if s.maxDepth > 0 && s.count >= s.maxDepth {
+		s.evict(evicted)
+	}
+	if s.count == len(s.items) {
+		s.grow(1)
+	}
+	s.items[s.index(s.count)] = item
+	s.count++
+}
+
+// shrink halves the capacity of the buffer when the stack has grown
+// significantly and is mostly empty. It must be called with s.mu held.
+func (s *Stack[T]) shrink() {
+	// Reduce capacity if:
+	//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
+	//   - current capacity exceeds 2× the initial capacity (if any),
+	//   - and fewer than 12.5% of elements are in use (cap/8).
+	//
+	// Why 1/8 instead of 1/4?
+	//   Using 1/4 is fine for general use, but in tight push/pop workloads
+	//   it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
+	//   only when the queue is significantly underutilized.
+	//
+	// Why halve capacity?
+	//   Halving avoids repeated reallocations while still reclaiming
+	//   unused memory proportionally. It balances memory efficiency and speed.
+	capNow := len(s.items)
+	if capNow > shrinkCapacityThreshold &&
+		(s.initialCapacity == 0 || capNow > s.initialCapacity*2) &&
+		s.count < capNow/8 {
+
+		newCap := capNow / 2
+		if s.initialCapacity > 0 && newCap < s.initialCapacity {
+			newCap = s.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			s.resize(newCap)
+		}
+	}
+}
+
+// evict drops the bottom element of the stack in O(1) time and, if there
+// is an eviction callback, appends the element to evicted so that it can
+// be reported once the lock is released. It must be called with s.mu held.
+func (s *Stack[T]) evict(evicted *[]T) {
+	var zero T
+	item := s.items[s.head]
+	s.items[s.head] = zero // release the reference for the GC
+	s.head = s.index(1)
+	s.count--
+	if s.onEvict != nil {
+		*evicted = append(*evicted, item)
+	}
+}
+
+// notify reports every evicted element to the eviction callback, if any.
+// It must be called without holding s.mu.
+func (s *Stack[T]) notify(evicted *[]T) {
+	for _, item := range *evicted {
+		s.onEvict(item)
+	}
 }
 
 // noCopy may be added to structs which must not be copied
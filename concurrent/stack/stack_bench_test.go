@@ -179,3 +179,137 @@ func BenchmarkStack_ConcurrentPushPop(b *testing.B) {
 		s.Clear()
 	}
 }
+
+func BenchmarkStack_BoundedPush(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewBounded[int](1000)
+	for b.Loop() {
+		s.Push(1)
+	}
+}
+
+func BenchmarkStack_RollPopN(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	s.PushMany(1, 2, 3, 4)
+	for b.Loop() {
+		s.Roll(3)
+		s.Over()
+		s.PopN(2)
+		s.Push(0)
+	}
+}
+
+// The LockFree benchmarks mirror the Stack ones above, so the lock-free
+// stack can be compared with the mutex-based one. The Parallel benchmarks
+// pair pushes and pops on every goroutine, which is where the elimination
+// array is meant to help.
+func BenchmarkLockFree_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewLockFree[int]()
+	for b.Loop() {
+		s.Push(1)
+	}
+}
+
+func BenchmarkLockFree_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewLockFree[int]()
+	for i := 0; i < b.N; i++ {
+		s.Push(1)
+	}
+	for b.Loop() {
+		s.Pop()
+	}
+}
+
+func BenchmarkLockFree_ConcurrentPush(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	const goroutines = 8
+	s := NewLockFree[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					s.Push(j)
+				}
+			}()
+		}
+		wg.Wait()
+		s.Clear()
+	}
+}
+
+func BenchmarkLockFree_ConcurrentPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	const goroutines = 4
+	s := NewLockFree[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines * 2)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					s.Push(j)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					s.Pop()
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkStack_ParallelPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
+
+func BenchmarkLockFree_ParallelPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewLockFree[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
+
+func BenchmarkLockFreeElimination_ParallelPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewLockFreeWithElimination[int](0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
@@ -2,6 +2,7 @@ package stack_test
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/khavishbhundoo/collections/concurrent/stack"
@@ -60,3 +61,71 @@ func ExampleStack() {
 	// 1 true
 	// 3
 }
+
+func ExampleLockFree() {
+	s := stack.NewLockFreeWithElimination[int](0)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			s.Push(v)
+		}(i)
+	}
+	wg.Wait()
+	fmt.Println(s.Len())
+
+	s.Clear()
+	s.PushMany(1, 2, 3)
+	fmt.Println(slices.Collect(s.All()))
+	val, ok := s.Pop()
+	fmt.Println(val, ok)
+
+	// Output:
+	// 4
+	// [3 2 1]
+	// 3 true
+}
+
+func ExampleNewBoundedWithEvict() {
+	// An undo history that remembers the last three edits
+	history := stack.NewBoundedWithEvict(3, func(edit string) {
+		fmt.Println("forgot", edit)
+	})
+	for _, edit := range []string{"type a", "type b", "delete", "paste"} {
+		history.Push(edit)
+	}
+	fmt.Println(history.Len())
+
+	edit, _ := history.Pop()
+	fmt.Println("undo", edit)
+
+	// Output:
+	// forgot type a
+	// 3
+	// undo paste
+}
+
+func ExampleStack_PopN() {
+	// Workers share an operand stack; PopN takes both operands of a
+	// binary operation in one step, so no other worker can take one
+	s := stack.New[int]()
+	s.PushMany(6, 7)
+	if _, err := s.Dup(); err != nil {
+		fmt.Println(err)
+	}
+	args, ok := s.PopN(2)
+	fmt.Println(args, ok)
+	s.Push(args[0] * args[1])
+	fmt.Println(s.PeekN(2))
+
+	// Nothing to duplicate once the stack is empty
+	s.Drop(2)
+	fmt.Println(s.Dup())
+
+	// Output:
+	// [7 7] true
+	// [6 49] true
+	// false <nil>
+}
@@ -0,0 +1,331 @@
+package deque
+
+import "iter"
+
+// Deque is a generic, non-thread-safe double-ended queue implementation
+// backed by a dynamically resizing ring buffer. Items can be pushed and
+// popped at both ends in amortized O(1) time and accessed by index in O(1).
+// The zero value of Deque[T] is ready to use without initialization.
+//
+// Use New() or NewWithCapacity() if you prefer an explicit constructor
+// or want to set an initial capacity.
+// If you do need thread-safety, use the collections/concurrent/deque package instead.
+type Deque[T any] struct {
+	items           []T // ring buffer; len(items) is the capacity
+	head            int // index of the front element
+	count           int // number of elements in the deque
+	initialCapacity int
+}
+
+// shrinkCapacityThreshold defines the minimum buffer capacity before
+// shrink operations are considered. Avoids aggressive shrinking for
+// small deques that would just grow again.
+// shrinkCapacityThreshold is just one parameter when deciding to shrink
+// the underlying array
+const shrinkCapacityThreshold = 16
+
+// New creates an empty deque of type T with no pre-allocated capacity.
+// Use this when you don't know in advance how many elements you will push.
+// This is equivalent to creating a deque as `var d deque.Deque[int]`
+//
+// Example:
+//
+//	d := deque.New[int]()
+func New[T any]() *Deque[T] {
+	return &Deque[T]{
+		items:           []T{},
+		initialCapacity: 0,
+	}
+}
+
+// NewWithCapacity creates an empty deque of type T with a pre-allocated
+// capacity. This avoids repeated allocations if you know roughly how
+// many elements you’ll push.
+//
+// Example:
+//
+//	d := deque.NewWithCapacity[int](10)
+func NewWithCapacity[T any](capacity int) *Deque[T] {
+	return &Deque[T]{
+		items:           make([]T, capacity),
+		initialCapacity: capacity,
+	}
+}
+
+// FromSeq creates a deque holding the values of seq in the order they
+// are produced, so the first value yielded is the front of the deque.
+//
+// Example:
+//
+//	d := deque.FromSeq(slices.Values([]int{1, 2, 3}))
+func FromSeq[T any](seq iter.Seq[T]) *Deque[T] {
+	d := New[T]()
+	for v := range seq {
+		d.PushBack(v)
+	}
+	return d
+}
+
+// PushBack adds a single item to the back of the deque.
+//
+// Example:
+//
+//	d.PushBack(42)
+func (d *Deque[T]) PushBack(item T) {
+	if d.count == len(d.items) {
+		d.grow()
+	}
+	d.items[d.index(d.count)] = item
+	d.count++
+}
+
+// PushFront adds a single item to the front of the deque.
+//
+// Example:
+//
+//	d.PushFront(42)
+func (d *Deque[T]) PushFront(item T) {
+	if d.count == len(d.items) {
+		d.grow()
+	}
+	d.head--
+	if d.head < 0 {
+		d.head += len(d.items)
+	}
+	d.items[d.head] = item
+	d.count++
+}
+
+// PopFront removes and returns the element at the front of the deque.
+// The boolean return is false if the deque is empty.
+// The deque may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+//
+// Example:
+//
+//	value, ok := d.PopFront()
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+	item := d.items[d.head]
+	d.items[d.head] = zero // release the reference for the GC
+	d.head = d.index(1)
+	d.count--
+	d.shrinkIfNeeded()
+	return item, true
+}
+
+// PopBack removes and returns the element at the back of the deque.
+// The boolean return is false if the deque is empty.
+// The deque may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+//
+// Example:
+//
+//	value, ok := d.PopBack()
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+	tail := d.index(d.count - 1)
+	item := d.items[tail]
+	d.items[tail] = zero // release the reference for the GC
+	d.count--
+	d.shrinkIfNeeded()
+	return item, true
+}
+
+// PeekFront returns the front of the deque without removing it.
+// The boolean return is false if the deque is empty.
+//
+// Example:
+//
+//	value, ok := d.PeekFront()
+func (d *Deque[T]) PeekFront() (T, bool) {
+	if d.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.head], true
+}
+
+// PeekBack returns the back of the deque without removing it.
+// The boolean return is false if the deque is empty.
+//
+// Example:
+//
+//	value, ok := d.PeekBack()
+func (d *Deque[T]) PeekBack() (T, bool) {
+	if d.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.index(d.count-1)], true
+}
+
+// At returns the element at position i, counting from the front of the
+// deque, in O(1) time. The boolean return is false if i is out of range.
+//
+// Example:
+//
+//	value, ok := d.At(0) // same as d.PeekFront()
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.count {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.index(i)], true
+}
+
+// Len returns the current number of items in the deque.
+//
+// Example:
+//
+//	n := d.Len()
+func (d *Deque[T]) Len() int {
+	return d.count
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying buffer. This is faster than Clear()
+// when you expect to reuse the same deque size.
+//
+// Example:
+//
+//	d.Reset()
+func (d *Deque[T]) Reset() {
+	clear(d.items)
+	d.head = 0
+	d.count = 0
+}
+
+// Clear removes all items and reallocates a buffer with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+//
+// Example:
+//
+//	d.Clear()
+func (d *Deque[T]) Clear() {
+	d.items = make([]T, d.initialCapacity)
+	d.head = 0
+	d.count = 0
+}
+
+// All returns an iterator over the items of the deque from front to back,
+// without removing them. The deque must not be modified while the
+// iteration is in progress.
+//
+// Example:
+//
+//	for v := range d.All() { fmt.Println(v) }
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.count; i++ {
+			if !yield(d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the deque from back to
+// front, without removing them. The deque must not be modified while the
+// iteration is in progress.
+//
+// Example:
+//
+//	for v := range d.Backward() { fmt.Println(v) }
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.count - 1; i >= 0; i-- {
+			if !yield(d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the position in the ring buffer of the element
+// that is offset places behind the front of the deque.
+func (d *Deque[T]) index(offset int) int {
+	i := d.head + offset
+	if i >= len(d.items) {
+		i -= len(d.items)
+	}
+	return i
+}
+
+// grow doubles the capacity of the buffer to make room for one more element.
+func (d *Deque[T]) grow() {
+	d.resize(max(len(d.items)*2, 1))
+}
+
+// shrinkIfNeeded halves the capacity of the buffer when it is mostly empty.
+//
+// Reduce capacity if:
+//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
+//   - current capacity exceeds 2× the initial capacity (if any),
+//   - and fewer than 12.5% of elements are in use (cap/8).
+//
+// Why 1/8 instead of 1/4?
+//
+//	Using 1/4 is fine for general use, but in tight push/pop workloads
+//	it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
+//	only when the deque is significantly underutilized.
+//
+// Why halve capacity?
+//
+//	Halving avoids repeated reallocations while still reclaiming
+//	unused memory proportionally. It balances memory efficiency and speed.
+func (d *Deque[T]) shrinkIfNeeded() {
+	capNow := len(d.items)
+	if capNow > shrinkCapacityThreshold &&
+		(d.initialCapacity == 0 || capNow > d.initialCapacity*2) &&
+		d.count < capNow/8 {
+
+		newCap := capNow / 2
+		if d.initialCapacity > 0 && newCap < d.initialCapacity {
+			newCap = d.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			d.resize(newCap)
+		}
+	}
+}
+
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the front of the deque is at index 0.
+func (d *Deque[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if d.count > 0 {
+		n := copy(newItems, d.items[d.head:min(d.head+d.count, len(d.items))])
+		copy(newItems[n:], d.items[:d.count-n])
+	}
+	d.items = newItems
+	d.head = 0
+}
+
+// ordered returns a copy of the items of the deque from front to back.
+func (d *Deque[T]) ordered() []T {
+	items := make([]T, d.count)
+	if d.count > 0 {
+		n := copy(items, d.items[d.head:min(d.head+d.count, len(d.items))])
+		copy(items[n:], d.items[:d.count-n])
+	}
+	return items
+}
+
+// replace makes items the contents of the deque, the first item becoming
+// the front. The buffer keeps at least the initial capacity.
+func (d *Deque[T]) replace(items []T) {
+	if len(items) < d.initialCapacity {
+		items = append(make([]T, 0, d.initialCapacity), items...)
+	}
+	d.items = items[:cap(items)]
+	d.head = 0
+	d.count = len(items)
+}
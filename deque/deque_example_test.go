@@ -0,0 +1,61 @@
+package deque_test
+
+import (
+	"fmt"
+
+	"github.com/khavishbhundoo/collections/deque"
+)
+
+func ExampleDeque() {
+	d := deque.New[int]()
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+
+	val, ok := d.PeekFront()
+	fmt.Println(val, ok)
+	val, ok = d.PeekBack()
+	fmt.Println(val, ok)
+	val, ok = d.At(1)
+	fmt.Println(val, ok)
+
+	val, ok = d.PopFront()
+	fmt.Println(val, ok)
+	val, ok = d.PopBack()
+	fmt.Println(val, ok)
+	fmt.Println(d.Len())
+
+	// The zero value of Deque[T] is ready to use without initialization
+	var d2 deque.Deque[int]
+	d2.PushFront(1)
+	val, ok = d2.PopBack()
+	fmt.Println(val, ok)
+	val, ok = d2.PopBack()
+	fmt.Println(val, ok)
+
+	// Output:
+	// 1 true
+	// 3 true
+	// 2 true
+	// 1 true
+	// 3 true
+	// 1
+	// 1 true
+	// 0 false
+}
+
+func ExampleDeque_Backward() {
+	d := deque.NewWithCapacity[string](3)
+	d.PushBack("b")
+	d.PushBack("c")
+	d.PushFront("a")
+
+	for v := range d.Backward() {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// c
+	// b
+	// a
+}
@@ -0,0 +1,206 @@
+package deque
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeque_New(t *testing.T) {
+	d := New[int]()
+	if cap(d.items) != 0 {
+		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(d.items))
+	}
+
+	d.PushBack(1)
+	r, ok := d.PopFront()
+	if !ok || r != 1 {
+		t.Errorf("PopFront(): expected %d, got %d", 1, r)
+	}
+}
+
+func TestDeque_NewWithCapacity(t *testing.T) {
+	d := NewWithCapacity[int](5)
+	d.PushBack(1)
+
+	if d.Len() != 1 {
+		t.Errorf("Len(): expected %d, got %d", 1, d.Len())
+	}
+	if cap(d.items) != 5 {
+		t.Errorf("Initial capacity: expected %d, got %d", 5, cap(d.items))
+	}
+}
+
+func TestDeque_PushPopBothEnds(t *testing.T) {
+	var d Deque[int] // zero-value
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	if got := slices.Collect(d.All()); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{0, 1, 2, 3}, got)
+	}
+	if got := slices.Collect(d.Backward()); !slices.Equal(got, []int{3, 2, 1, 0}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{3, 2, 1, 0}, got)
+	}
+
+	if r, ok := d.PeekFront(); !ok || r != 0 {
+		t.Errorf("PeekFront(): expected %d, got %d", 0, r)
+	}
+	if r, ok := d.PeekBack(); !ok || r != 3 {
+		t.Errorf("PeekBack(): expected %d, got %d", 3, r)
+	}
+
+	if r, ok := d.PopBack(); !ok || r != 3 {
+		t.Errorf("PopBack(): expected %d, got %d", 3, r)
+	}
+	if r, ok := d.PopFront(); !ok || r != 0 {
+		t.Errorf("PopFront(): expected %d, got %d", 0, r)
+	}
+	if d.Len() != 2 {
+		t.Errorf("Len(): expected %d, got %d", 2, d.Len())
+	}
+}
+
+func TestDeque_EmptyPopPeek(t *testing.T) {
+	var d Deque[int]
+
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront() on empty deque: expected NOK, got OK")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack() on empty deque: expected NOK, got OK")
+	}
+	if _, ok := d.PeekFront(); ok {
+		t.Errorf("PeekFront() on empty deque: expected NOK, got OK")
+	}
+	if _, ok := d.PeekBack(); ok {
+		t.Errorf("PeekBack() on empty deque: expected NOK, got OK")
+	}
+	if _, ok := d.At(0); ok {
+		t.Errorf("At(0) on empty deque: expected NOK, got OK")
+	}
+}
+
+func TestDeque_At(t *testing.T) {
+	d := NewWithCapacity[int](4)
+	d.PushBack(1)
+	d.PushBack(2)
+	d.PushFront(0) // wraps around to the end of the buffer
+
+	for i, val := range []int{0, 1, 2} {
+		r, ok := d.At(i)
+		if !ok || r != val {
+			t.Errorf("At(%d): expected %d, got %d", i, val, r)
+		}
+	}
+	if _, ok := d.At(-1); ok {
+		t.Errorf("At(-1): expected NOK, got OK")
+	}
+	if _, ok := d.At(3); ok {
+		t.Errorf("At(3): expected NOK, got OK")
+	}
+}
+
+func TestDeque_WrapAround(t *testing.T) {
+	d := NewWithCapacity[int](4)
+	d.PushFront(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	d.PushBack(4)
+	if cap(d.items) != 4 {
+		t.Errorf("Capacity: expected %d, got %d", 4, cap(d.items))
+	}
+
+	// Growing a wrapped buffer must keep the order
+	d.PushFront(0)
+	d.PushBack(5)
+	if got := slices.Collect(d.All()); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("All(): expected %v, got %v", []int{0, 1, 2, 3, 4, 5}, got)
+	}
+}
+
+func TestDeque_PopReleasesReference(t *testing.T) {
+	d := NewWithCapacity[*int](4)
+	v := 1
+	d.PushBack(&v)
+	d.PushBack(&v)
+	d.PopFront()
+	d.PopBack()
+
+	for i, p := range d.items {
+		if p != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestDeque_Reset(t *testing.T) {
+	d := NewWithCapacity[int](5)
+	d.PushBack(1)
+	d.PushFront(2)
+
+	d.Reset()
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront() after Reset(): expected NOK, got OK")
+	}
+	if cap(d.items) != 5 {
+		t.Errorf("Capacity after Reset(): expected %d, got %d", 5, cap(d.items))
+	}
+}
+
+func TestDeque_Clear(t *testing.T) {
+	d := NewWithCapacity[int](2)
+	d.PushBack(1)
+	d.PushBack(2)
+	d.PushBack(3)
+
+	d.Clear()
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack() after Clear(): expected NOK, got OK")
+	}
+	if cap(d.items) != 2 {
+		t.Errorf("Capacity after Clear(): expected %d, got %d", 2, cap(d.items))
+	}
+}
+
+func TestDeque_Shrink(t *testing.T) {
+	initialCap := 64
+	d := NewWithCapacity[int](initialCap)
+
+	// Fill the deque past twice its initial capacity
+	for i := 0; i < 200; i++ {
+		d.PushBack(i)
+	}
+	peakCap := cap(d.items)
+
+	// Pop from both ends to trigger shrink
+	for i := 0; i < 95; i++ {
+		d.PopFront()
+		d.PopBack()
+	}
+
+	if cap(d.items) >= peakCap {
+		t.Errorf("Expected capacity to shrink below peak %d, got %d", peakCap, cap(d.items))
+	}
+	if cap(d.items) < initialCap {
+		t.Errorf("Expected capacity to stay at or above initial capacity %d, got %d", initialCap, cap(d.items))
+	}
+
+	for i := 95; i < 105; i++ {
+		r, ok := d.PopFront()
+		if !ok || r != i {
+			t.Errorf("Expected PopFront() to return %d, got %d", i, r)
+		}
+	}
+}
+
+func TestDeque_FromSeq(t *testing.T) {
+	d := FromSeq(slices.Values([]int{1, 2, 3}))
+	for _, val := range []int{3, 2, 1} {
+		r, ok := d.PopBack()
+		if !ok || r != val {
+			t.Errorf("PopBack(): expected %d, got %d", val, r)
+		}
+	}
+}
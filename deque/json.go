@@ -0,0 +1,31 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The deque is encoded as a JSON
+// array holding its items from front to back. An empty deque encodes as [].
+//
+// MarshalJSON has a value receiver so that a Deque embedded by value in
+// another struct is encoded even when that struct is not addressable.
+func (d Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ordered())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the deque with the items of a JSON array, the first element becoming the
+// front. As is conventional, a JSON null leaves the deque unchanged.
+// On error the deque is not modified.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	d.replace(items)
+	return nil
+}
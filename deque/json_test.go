@@ -0,0 +1,40 @@
+package deque
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestDeque_JSON(t *testing.T) {
+	d := NewWithCapacity[int](4)
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1) // wraps around the ring buffer
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Marshal(): expected %s, got %s", "[1,2,3]", data)
+	}
+
+	var decoded Deque[int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Unmarshal(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	decoded.PushFront(0)
+	if r, _ := decoded.PeekFront(); r != 0 {
+		t.Errorf("PeekFront() after Unmarshal(): expected %d, got %d", 0, r)
+	}
+
+	var empty Deque[int]
+	data, _ = json.Marshal(empty)
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty deque: expected %s, got %s", "[]", data)
+	}
+}
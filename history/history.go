@@ -0,0 +1,196 @@
+// Package history provides an undo/redo history built from a pair of
+// stacks from the collections/stack package.
+package history
+
+import (
+	"slices"
+
+	"github.com/khavishbhundoo/collections/stack"
+)
+
+// History is a generic, non-thread-safe undo/redo history of actions of
+// type T. It records what was done and hands actions back to the caller
+// when they are undone or redone; applying and reverting them is up to
+// the caller. The zero value of History[T] is ready to use and has no
+// depth limit.
+//
+// Every call to Do records a step on the undo stack and clears the redo
+// stack. Undo moves the latest step to the redo stack and Redo moves it
+// back. Actions done between BeginGroup and EndGroup form a single step,
+// so they are undone and redone together.
+//
+// Use New() for an unbounded history or NewWithMaxDepth() to remember
+// only the latest steps.
+type History[T any] struct {
+	undo     *stack.Stack[[]T] // steps that can be undone; the top is the latest
+	redo     *stack.Stack[[]T] // steps that can be redone; the top is the latest undone
+	group    []T               // actions of the open group
+	depth    int               // nesting level of BeginGroup calls
+	ended    int               // groups ended early whose EndGroup is still to come
+	maxDepth int
+}
+
+// New creates an empty history with no depth limit.
+// This is equivalent to creating a history as `var h history.History[T]`
+func New[T any]() *History[T] {
+	return NewWithMaxDepth[T](0)
+}
+
+// NewWithMaxDepth creates an empty history that remembers at most maxDepth
+// steps to undo. Once the limit is reached, recording a new step forgets
+// the oldest one. A maxDepth of zero or less means the history is unbounded.
+func NewWithMaxDepth[T any](maxDepth int) *History[T] {
+	h := &History[T]{maxDepth: max(maxDepth, 0)}
+	h.lazyInit()
+	return h
+}
+
+// Do records action as done and clears the redo stack, since the undone
+// steps no longer apply. Inside a group, action is added to the group;
+// otherwise it becomes a step of its own.
+func (h *History[T]) Do(action T) {
+	h.lazyInit()
+	h.redo.Reset()
+	if h.depth > 0 {
+		h.group = append(h.group, action)
+		return
+	}
+	h.undo.Push([]T{action})
+}
+
+// Undo moves the latest step from the undo stack to the redo stack and
+// returns its actions in the order they should be reverted, the latest
+// first. The boolean return is false if there is nothing to undo.
+// An open group is ended first and can be undone; see EndGroup.
+func (h *History[T]) Undo() ([]T, bool) {
+	h.lazyInit()
+	h.endGroups()
+	step, ok := h.undo.Pop()
+	if !ok {
+		return nil, false
+	}
+	h.redo.Push(step)
+	actions := slices.Clone(step)
+	slices.Reverse(actions)
+	return actions, true
+}
+
+// Redo moves the latest undone step from the redo stack back to the undo
+// stack and returns its actions in the order they should be reapplied,
+// the order in which they were done. The boolean return is false if there
+// is nothing to redo. An open group is ended first; see EndGroup.
+func (h *History[T]) Redo() ([]T, bool) {
+	h.lazyInit()
+	h.endGroups()
+	step, ok := h.redo.Pop()
+	if !ok {
+		return nil, false
+	}
+	h.undo.Push(step)
+	return slices.Clone(step), true
+}
+
+// BeginGroup starts a group: the actions done until the matching EndGroup
+// form a single step. Groups may be nested; the actions of nested groups
+// belong to the outermost one.
+func (h *History[T]) BeginGroup() {
+	h.depth++
+}
+
+// EndGroup ends the group started by the matching BeginGroup. Ending the
+// outermost group records its actions as a single step; a group without
+// actions records nothing.
+//
+// Undo, Redo and Clear end every open group early, so the EndGroup calls
+// that match those groups have nothing left to end and do nothing. Any
+// other EndGroup without a matching BeginGroup panics.
+func (h *History[T]) EndGroup() {
+	if h.depth == 0 {
+		if h.ended == 0 {
+			panic("history: EndGroup without BeginGroup")
+		}
+		h.ended--
+		return
+	}
+	h.depth--
+	if h.depth > 0 || len(h.group) == 0 {
+		return
+	}
+	h.lazyInit()
+	h.undo.Push(h.group)
+	h.group = nil
+}
+
+// InGroup reports whether a group is open.
+func (h *History[T]) InGroup() bool {
+	return h.depth > 0
+}
+
+// CanUndo reports whether there is a step to undo, including the actions
+// of an open group.
+func (h *History[T]) CanUndo() bool {
+	return h.UndoLen() > 0
+}
+
+// CanRedo reports whether there is a step to redo.
+func (h *History[T]) CanRedo() bool {
+	return h.RedoLen() > 0
+}
+
+// UndoLen returns the number of steps that can be undone. An open group
+// with actions counts as one step.
+func (h *History[T]) UndoLen() int {
+	n := 0
+	if h.undo != nil {
+		n = h.undo.Len()
+	}
+	if len(h.group) > 0 {
+		n++
+		if h.maxDepth > 0 {
+			n = min(n, h.maxDepth) // ending the group evicts the oldest step
+		}
+	}
+	return n
+}
+
+// RedoLen returns the number of steps that can be redone.
+func (h *History[T]) RedoLen() int {
+	if h.redo == nil {
+		return 0
+	}
+	return h.redo.Len()
+}
+
+// MaxDepth returns the maximum number of steps the history remembers,
+// or 0 if it is unbounded.
+func (h *History[T]) MaxDepth() int {
+	return h.maxDepth
+}
+
+// Clear forgets every step, including the actions of an open group,
+// and ends all groups; see EndGroup.
+func (h *History[T]) Clear() {
+	h.lazyInit()
+	h.undo.Clear()
+	h.redo.Clear()
+	h.group = nil
+	h.ended += h.depth
+	h.depth = 0
+}
+
+// endGroups ends all open groups, recording their actions as a step, and
+// remembers them so that their own EndGroup calls do nothing.
+func (h *History[T]) endGroups() {
+	for h.depth > 0 {
+		h.ended++
+		h.EndGroup()
+	}
+}
+
+// lazyInit creates the stacks of a zero-value history.
+func (h *History[T]) lazyInit() {
+	if h.undo == nil {
+		h.undo = stack.NewBounded[[]T](h.maxDepth)
+		h.redo = stack.New[[]T]()
+	}
+}
@@ -0,0 +1,41 @@
+package history
+
+import (
+	"runtime"
+	"testing"
+)
+
+func BenchmarkHistory_Do(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	h := NewWithMaxDepth[int](1000)
+	for b.Loop() {
+		h.Do(1)
+	}
+}
+
+func BenchmarkHistory_UndoRedo(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	h := New[int]()
+	for i := 0; i < 1000; i++ {
+		h.Do(i)
+	}
+	for b.Loop() {
+		h.Undo()
+		h.Redo()
+	}
+}
+
+func BenchmarkHistory_Group(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	h := NewWithMaxDepth[int](1000)
+	for b.Loop() {
+		h.BeginGroup()
+		for i := 0; i < 8; i++ {
+			h.Do(i)
+		}
+		h.EndGroup()
+	}
+}
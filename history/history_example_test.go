@@ -0,0 +1,51 @@
+package history_test
+
+import (
+	"fmt"
+
+	"github.com/khavishbhundoo/collections/history"
+)
+
+// insert is an edit of a text buffer that can be applied and reverted.
+type insert struct {
+	pos  int
+	text string
+}
+
+func Example() {
+	text := ""
+	apply := func(e insert) { text = text[:e.pos] + e.text + text[e.pos:] }
+	revert := func(e insert) { text = text[:e.pos] + text[e.pos+len(e.text):] }
+
+	h := history.NewWithMaxDepth[insert](100)
+	do := func(e insert) {
+		apply(e)
+		h.Do(e)
+	}
+
+	do(insert{0, "Hello"})
+	h.BeginGroup() // a paste inserts several pieces as one step
+	do(insert{5, ","})
+	do(insert{6, " world"})
+	h.EndGroup()
+	fmt.Println(text)
+
+	edits, _ := h.Undo()
+	for _, e := range edits {
+		revert(e)
+	}
+	fmt.Println(text)
+
+	edits, _ = h.Redo()
+	for _, e := range edits {
+		apply(e)
+	}
+	fmt.Println(text)
+	fmt.Println(h.UndoLen(), h.RedoLen())
+
+	// Output:
+	// Hello, world
+	// Hello
+	// Hello, world
+	// 2 0
+}
@@ -0,0 +1,209 @@
+package history
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHistory_UndoRedo(t *testing.T) {
+	h := New[string]()
+	if h.CanUndo() || h.CanRedo() {
+		t.Errorf("New history: expected nothing to undo or redo")
+	}
+	if _, ok := h.Undo(); ok {
+		t.Errorf("Undo() on empty history: expected NOK, got OK")
+	}
+	if _, ok := h.Redo(); ok {
+		t.Errorf("Redo() on empty history: expected NOK, got OK")
+	}
+
+	h.Do("a")
+	h.Do("b")
+	if h.UndoLen() != 2 || h.RedoLen() != 0 {
+		t.Errorf("UndoLen(), RedoLen(): expected 2, 0, got %d, %d", h.UndoLen(), h.RedoLen())
+	}
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Undo(): expected %v, got %v", []string{"b"}, got)
+	}
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Undo(): expected %v, got %v", []string{"a"}, got)
+	}
+	if h.CanUndo() || h.RedoLen() != 2 {
+		t.Errorf("After undoing everything: expected 0 to undo and 2 to redo, got %d and %d", h.UndoLen(), h.RedoLen())
+	}
+	if got, ok := h.Redo(); !ok || !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Redo(): expected %v, got %v", []string{"a"}, got)
+	}
+
+	// Doing something new forgets what could be redone
+	h.Do("c")
+	if h.CanRedo() {
+		t.Errorf("Do(): expected the redo stack to be cleared, got RedoLen() = %d", h.RedoLen())
+	}
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []string{"c"}) {
+		t.Errorf("Undo(): expected %v, got %v", []string{"c"}, got)
+	}
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Undo(): expected %v, got %v", []string{"a"}, got)
+	}
+
+	h.Clear()
+	if h.CanUndo() || h.CanRedo() {
+		t.Errorf("Clear(): expected nothing to undo or redo")
+	}
+
+	var zero History[int] // zero-value
+	zero.Do(1)
+	if got, ok := zero.Undo(); !ok || !slices.Equal(got, []int{1}) {
+		t.Errorf("Undo() on zero value: expected %v, got %v", []int{1}, got)
+	}
+	if got, ok := zero.Redo(); !ok || !slices.Equal(got, []int{1}) {
+		t.Errorf("Redo() on zero value: expected %v, got %v", []int{1}, got)
+	}
+}
+
+func TestHistory_Groups(t *testing.T) {
+	h := New[int]()
+	h.Do(1)
+	h.BeginGroup()
+	h.Do(2)
+	h.BeginGroup() // nested groups belong to the outermost one
+	h.Do(3)
+	h.EndGroup()
+	if !h.InGroup() {
+		t.Errorf("InGroup(): expected true inside the outer group")
+	}
+	h.Do(4)
+	if h.UndoLen() != 2 {
+		t.Errorf("UndoLen() with an open group: expected %d, got %d", 2, h.UndoLen())
+	}
+	h.EndGroup()
+	if h.InGroup() {
+		t.Errorf("InGroup(): expected false after the outer group ended")
+	}
+
+	// A group is undone in reverse and redone in order
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []int{4, 3, 2}) {
+		t.Errorf("Undo(): expected %v, got %v", []int{4, 3, 2}, got)
+	}
+	if got, ok := h.Redo(); !ok || !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("Redo(): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+	if h.UndoLen() != 2 {
+		t.Errorf("UndoLen(): expected %d, got %d", 2, h.UndoLen())
+	}
+
+	// An empty group records nothing
+	h.BeginGroup()
+	h.EndGroup()
+	if h.UndoLen() != 2 {
+		t.Errorf("UndoLen() after an empty group: expected %d, got %d", 2, h.UndoLen())
+	}
+
+	// Undo ends an open group first
+	h.BeginGroup()
+	h.Do(5)
+	h.Do(6)
+	if got, ok := h.Undo(); !ok || !slices.Equal(got, []int{6, 5}) {
+		t.Errorf("Undo() with an open group: expected %v, got %v", []int{6, 5}, got)
+	}
+	if h.InGroup() {
+		t.Errorf("InGroup(): expected false after Undo()")
+	}
+	h.EndGroup() // the group is already ended: no panic, nothing recorded
+	if h.UndoLen() != 2 || h.RedoLen() != 1 {
+		t.Errorf("EndGroup() after Undo(): expected UndoLen() %d and RedoLen() %d, got %d and %d", 2, 1, h.UndoLen(), h.RedoLen())
+	}
+
+	// Undoing a step must not change what Redo returns
+	got, _ := h.Undo()
+	got[0] = 100
+	if got, _ := h.Redo(); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("Redo(): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EndGroup() without BeginGroup(): expected a panic")
+		}
+	}()
+	h.EndGroup()
+}
+
+func TestHistory_EndGroupAfterEarlyEnd(t *testing.T) {
+	h := New[int]()
+	h.Do(1)
+
+	// Undo ends both open groups; a group begun afterwards still works
+	h.BeginGroup()
+	h.BeginGroup()
+	h.Do(2)
+	h.Undo()
+	h.BeginGroup()
+	h.Do(3)
+	h.EndGroup()
+	h.EndGroup()
+	h.EndGroup()
+	if got, _ := h.Undo(); !slices.Equal(got, []int{3}) {
+		t.Errorf("Undo() of the group begun after Undo(): expected %v, got %v", []int{3}, got)
+	}
+
+	// Redo and Clear end open groups too
+	h.BeginGroup()
+	h.Redo()
+	h.EndGroup()
+	h.BeginGroup()
+	h.Clear()
+	h.EndGroup()
+	if h.InGroup() || h.UndoLen() != 0 {
+		t.Errorf("After Clear(): expected no group and no steps, got InGroup() %v and UndoLen() %d", h.InGroup(), h.UndoLen())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EndGroup() once the ended groups are matched: expected a panic")
+		}
+	}()
+	h.EndGroup()
+}
+
+func TestHistory_MaxDepth(t *testing.T) {
+	h := NewWithMaxDepth[int](3)
+	if h.MaxDepth() != 3 {
+		t.Errorf("MaxDepth(): expected %d, got %d", 3, h.MaxDepth())
+	}
+	for i := 1; i <= 5; i++ {
+		h.Do(i)
+	}
+	if h.UndoLen() != 3 {
+		t.Errorf("UndoLen(): expected %d, got %d", 3, h.UndoLen())
+	}
+	h.BeginGroup()
+	h.Do(6)
+	if h.UndoLen() != 3 {
+		t.Errorf("UndoLen() with an open group: expected %d, got %d", 3, h.UndoLen())
+	}
+	h.EndGroup()
+
+	var undone []int
+	for {
+		got, ok := h.Undo()
+		if !ok {
+			break
+		}
+		undone = append(undone, got...)
+	}
+	if !slices.Equal(undone, []int{6, 5, 4}) {
+		t.Errorf("Undo() of a bounded history: expected %v, got %v", []int{6, 5, 4}, undone)
+	}
+	for h.CanRedo() {
+		h.Redo()
+	}
+	if h.UndoLen() != 3 {
+		t.Errorf("UndoLen() after redoing everything: expected %d, got %d", 3, h.UndoLen())
+	}
+
+	if NewWithMaxDepth[int](-1).MaxDepth() != 0 {
+		t.Errorf("NewWithMaxDepth(-1): expected an unbounded history")
+	}
+}
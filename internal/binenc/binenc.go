@@ -0,0 +1,327 @@
+// Package binenc implements the versioned binary format shared by the
+// MarshalBinary and UnmarshalBinary methods of the collections.
+//
+// An encoded collection starts with a two-byte header, the format version
+// and the kind of collection, followed by the number of elements as an
+// unsigned varint. A sequence (queue, stack, set, deque) then holds one
+// column of elements; a map holds a column of keys followed by a column
+// of values, so that numeric keys and values both get the fixed-width form.
+//
+// A column starts with one byte giving the element width. A width of 1,
+// 2, 4 or 8 means every element is stored in that many little-endian
+// bytes. A width of 0 means every element is stored as an unsigned varint
+// length followed by that many bytes, as produced by the element's codec.
+package binenc
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+// version is the format version written by this package.
+const version = 1
+
+// Kinds of collection, stored after the version so that data written by
+// one kind of collection is not silently decoded by another.
+const (
+	kindSeq byte = 1
+	kindMap byte = 2
+)
+
+// prefixed is the column width for length-prefixed elements.
+const prefixed byte = 0
+
+// AppendSeq appends the encoding of a sequence holding items to b.
+func AppendSeq[T any](b []byte, items []T) ([]byte, error) {
+	b = append(b, version, kindSeq)
+	b = binary.AppendUvarint(b, uint64(len(items)))
+	return appendColumn(b, items)
+}
+
+// DecodeSeq decodes the items of a sequence encoded by AppendSeq.
+func DecodeSeq[T any](data []byte) ([]T, error) {
+	n, data, err := readHeader(data, kindSeq)
+	if err != nil {
+		return nil, err
+	}
+	items, data, err := decodeColumn[T](data, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", codec.ErrCorrupt, len(data))
+	}
+	return items, nil
+}
+
+// AppendMap appends the encoding of a map to b, where values[i] is the
+// value associated with keys[i].
+func AppendMap[K comparable, V any](b []byte, keys []K, values []V) ([]byte, error) {
+	b = append(b, version, kindMap)
+	b = binary.AppendUvarint(b, uint64(len(keys)))
+	b, err := appendColumn(b, keys)
+	if err != nil {
+		return nil, err
+	}
+	return appendColumn(b, values)
+}
+
+// DecodeMap decodes the keys and values of a map encoded by AppendMap,
+// where values[i] is the value associated with keys[i].
+func DecodeMap[K comparable, V any](data []byte) (keys []K, values []V, err error) {
+	n, data, err := readHeader(data, kindMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	if keys, data, err = decodeColumn[K](data, n); err != nil {
+		return nil, nil, err
+	}
+	if values, data, err = decodeColumn[V](data, n); err != nil {
+		return nil, nil, err
+	}
+	if len(data) != 0 {
+		return nil, nil, fmt.Errorf("%w: %d trailing bytes", codec.ErrCorrupt, len(data))
+	}
+	return keys, values, nil
+}
+
+// readHeader checks the version and kind of data and returns the number
+// of elements and the rest of the data.
+func readHeader(data []byte, kind byte) (int, []byte, error) {
+	if len(data) < 2 {
+		return 0, nil, fmt.Errorf("%w: missing header", codec.ErrCorrupt)
+	}
+	if data[0] != version {
+		return 0, nil, fmt.Errorf("%w %d", codec.ErrVersion, data[0])
+	}
+	if data[1] != kind {
+		return 0, nil, fmt.Errorf("%w: unexpected collection kind %d", codec.ErrCorrupt, data[1])
+	}
+	n, k := binary.Uvarint(data[2:])
+	if k <= 0 || n > math.MaxInt32 {
+		return 0, nil, fmt.Errorf("%w: invalid length", codec.ErrCorrupt)
+	}
+	return int(n), data[2+k:], nil
+}
+
+// appendColumn appends items to b, using a registered codec if there is
+// one, the fixed-width form for numeric types, or the built-in codec.
+func appendColumn[T any](b []byte, items []T) ([]byte, error) {
+	if c, ok := codec.Lookup[T](); ok {
+		return appendPrefixed(b, items, c.AppendBinary)
+	}
+	if b, ok := appendFixed(b, items); ok {
+		return b, nil
+	}
+	enc, _, err := builtin[T]()
+	if err != nil {
+		return nil, err
+	}
+	return appendPrefixed(b, items, enc)
+}
+
+// decodeColumn decodes a column of n items from data, mirroring
+// appendColumn, and returns the rest of the data.
+func decodeColumn[T any](data []byte, n int) ([]T, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing column", codec.ErrCorrupt)
+	}
+	width, data := data[0], data[1:]
+	if c, ok := codec.Lookup[T](); ok {
+		return decodePrefixed(data, width, n, c.DecodeBinary)
+	}
+	if items, rest, ok, err := decodeFixed[T](data, width, n); ok {
+		return items, rest, err
+	}
+	_, dec, err := builtin[T]()
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodePrefixed(data, width, n, dec)
+}
+
+// appendPrefixed appends a column of length-prefixed items encoded by enc.
+func appendPrefixed[T any](b []byte, items []T, enc func([]byte, T) ([]byte, error)) ([]byte, error) {
+	b = append(b, prefixed)
+	var scratch []byte
+	for _, v := range items {
+		var err error
+		if scratch, err = enc(scratch[:0], v); err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(b, uint64(len(scratch)))
+		b = append(b, scratch...)
+	}
+	return b, nil
+}
+
+// decodePrefixed decodes a column of n length-prefixed items with dec.
+func decodePrefixed[T any](data []byte, width byte, n int, dec func([]byte) (T, error)) ([]T, []byte, error) {
+	if width != prefixed {
+		return nil, nil, fmt.Errorf("%w: unexpected element width %d", codec.ErrCorrupt, width)
+	}
+	// Every element takes at least one byte for its length, which bounds
+	// the allocation when the length in the header is corrupt.
+	if n > len(data) {
+		return nil, nil, fmt.Errorf("%w: truncated data", codec.ErrCorrupt)
+	}
+	items := make([]T, n)
+	for i := range items {
+		size, k := binary.Uvarint(data)
+		if k <= 0 || size > uint64(len(data)-k) {
+			return nil, nil, fmt.Errorf("%w: truncated data", codec.ErrCorrupt)
+		}
+		v, err := dec(data[k : k+int(size)])
+		if err != nil {
+			return nil, nil, err
+		}
+		items[i] = v
+		data = data[k+int(size):]
+	}
+	return items, data, nil
+}
+
+// builtin returns the encoder and decoder of the element types that need
+// no registered codec: strings, byte slices, and types implementing
+// encoding.BinaryMarshaler whose pointer implements encoding.BinaryUnmarshaler.
+func builtin[T any]() (func([]byte, T) ([]byte, error), func([]byte) (T, error), error) {
+	var zero T
+	switch any(zero).(type) {
+	case string:
+		return func(b []byte, v T) ([]byte, error) {
+				return append(b, any(v).(string)...), nil
+			}, func(data []byte) (T, error) {
+				return any(string(data)).(T), nil
+			}, nil
+	case []byte:
+		return func(b []byte, v T) ([]byte, error) {
+				return append(b, any(v).([]byte)...), nil
+			}, func(data []byte) (T, error) {
+				return any(bytes.Clone(data)).(T), nil
+			}, nil
+	}
+	_, canMarshal := any(zero).(encoding.BinaryMarshaler)
+	_, canUnmarshal := any(&zero).(encoding.BinaryUnmarshaler)
+	if canMarshal && canUnmarshal {
+		return func(b []byte, v T) ([]byte, error) {
+				data, err := any(v).(encoding.BinaryMarshaler).MarshalBinary()
+				return append(b, data...), err
+			}, func(data []byte) (T, error) {
+				var v T
+				err := any(&v).(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+				return v, err
+			}, nil
+	}
+	return nil, nil, fmt.Errorf("%w %v", codec.ErrNoCodec, reflect.TypeFor[T]())
+}
+
+// appendFixed appends items in the fixed-width form if T is one of the
+// numeric types or bool, and reports whether it did.
+func appendFixed[T any](b []byte, items []T) ([]byte, bool) {
+	le := binary.LittleEndian
+	switch s := any(items).(type) {
+	case []bool:
+		return fixed(b, s, 1, func(b []byte, v bool) []byte {
+			if v {
+				return append(b, 1)
+			}
+			return append(b, 0)
+		}), true
+	case []int8:
+		return fixed(b, s, 1, func(b []byte, v int8) []byte { return append(b, byte(v)) }), true
+	case []uint8:
+		return append(append(b, 1), s...), true
+	case []int16:
+		return fixed(b, s, 2, func(b []byte, v int16) []byte { return le.AppendUint16(b, uint16(v)) }), true
+	case []uint16:
+		return fixed(b, s, 2, le.AppendUint16), true
+	case []int32:
+		return fixed(b, s, 4, func(b []byte, v int32) []byte { return le.AppendUint32(b, uint32(v)) }), true
+	case []uint32:
+		return fixed(b, s, 4, le.AppendUint32), true
+	case []float32:
+		return fixed(b, s, 4, func(b []byte, v float32) []byte { return le.AppendUint32(b, math.Float32bits(v)) }), true
+	case []int64:
+		return fixed(b, s, 8, func(b []byte, v int64) []byte { return le.AppendUint64(b, uint64(v)) }), true
+	case []uint64:
+		return fixed(b, s, 8, le.AppendUint64), true
+	case []float64:
+		return fixed(b, s, 8, func(b []byte, v float64) []byte { return le.AppendUint64(b, math.Float64bits(v)) }), true
+	case []int: // always 8 bytes, so that data is portable across platforms
+		return fixed(b, s, 8, func(b []byte, v int) []byte { return le.AppendUint64(b, uint64(v)) }), true
+	case []uint:
+		return fixed(b, s, 8, func(b []byte, v uint) []byte { return le.AppendUint64(b, uint64(v)) }), true
+	default:
+		return b, false
+	}
+}
+
+// decodeFixed decodes n items in the fixed-width form if T is one of the
+// numeric types or bool, and reports whether it did.
+func decodeFixed[T any](data []byte, width byte, n int) (items []T, rest []byte, ok bool, err error) {
+	le := binary.LittleEndian
+	switch p := any(&items).(type) {
+	case *[]bool:
+		*p, rest, err = unfixed(data, width, 1, n, func(b []byte) bool { return b[0] != 0 })
+	case *[]int8:
+		*p, rest, err = unfixed(data, width, 1, n, func(b []byte) int8 { return int8(b[0]) })
+	case *[]uint8:
+		*p, rest, err = unfixed(data, width, 1, n, func(b []byte) uint8 { return b[0] })
+	case *[]int16:
+		*p, rest, err = unfixed(data, width, 2, n, func(b []byte) int16 { return int16(le.Uint16(b)) })
+	case *[]uint16:
+		*p, rest, err = unfixed(data, width, 2, n, le.Uint16)
+	case *[]int32:
+		*p, rest, err = unfixed(data, width, 4, n, func(b []byte) int32 { return int32(le.Uint32(b)) })
+	case *[]uint32:
+		*p, rest, err = unfixed(data, width, 4, n, le.Uint32)
+	case *[]float32:
+		*p, rest, err = unfixed(data, width, 4, n, func(b []byte) float32 { return math.Float32frombits(le.Uint32(b)) })
+	case *[]int64:
+		*p, rest, err = unfixed(data, width, 8, n, func(b []byte) int64 { return int64(le.Uint64(b)) })
+	case *[]uint64:
+		*p, rest, err = unfixed(data, width, 8, n, le.Uint64)
+	case *[]float64:
+		*p, rest, err = unfixed(data, width, 8, n, func(b []byte) float64 { return math.Float64frombits(le.Uint64(b)) })
+	case *[]int:
+		*p, rest, err = unfixed(data, width, 8, n, func(b []byte) int { return int(le.Uint64(b)) })
+	case *[]uint:
+		*p, rest, err = unfixed(data, width, 8, n, func(b []byte) uint { return uint(le.Uint64(b)) })
+	default:
+		return nil, nil, false, nil
+	}
+	return items, rest, true, err
+}
+
+// fixed appends a column of items of the given width, each written by put.
+func fixed[E any](b []byte, items []E, width byte, put func([]byte, E) []byte) []byte {
+	b = append(b, width)
+	b = slices.Grow(b, len(items)*int(width))
+	for _, v := range items {
+		b = put(b, v)
+	}
+	return b
+}
+
+// unfixed decodes a column of n items of the given width, each read by get.
+func unfixed[E any](data []byte, gotWidth, width byte, n int, get func([]byte) E) ([]E, []byte, error) {
+	if gotWidth != width {
+		return nil, nil, fmt.Errorf("%w: unexpected element width %d", codec.ErrCorrupt, gotWidth)
+	}
+	size := n * int(width)
+	if size > len(data) {
+		return nil, nil, fmt.Errorf("%w: truncated data", codec.ErrCorrupt)
+	}
+	items := make([]E, n)
+	for i := range items {
+		items[i] = get(data[i*int(width):])
+	}
+	return items, data[size:], nil
+}
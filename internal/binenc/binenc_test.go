@@ -0,0 +1,153 @@
+package binenc
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+func roundTrip[T any](t *testing.T, items []T, equal func(a, b T) bool) {
+	t.Helper()
+	data, err := AppendSeq(nil, items)
+	if err != nil {
+		t.Fatalf("AppendSeq(%T): unexpected error %v", items, err)
+	}
+	got, err := DecodeSeq[T](data)
+	if err != nil {
+		t.Fatalf("DecodeSeq(%T): unexpected error %v", items, err)
+	}
+	if !slices.EqualFunc(got, items, equal) {
+		t.Errorf("DecodeSeq(%T): expected %v, got %v", items, items, got)
+	}
+}
+
+func eq[T comparable](a, b T) bool { return a == b }
+
+func TestSeq_RoundTrip(t *testing.T) {
+	roundTrip(t, []bool{true, false, true}, eq)
+	roundTrip(t, []int8{math.MinInt8, 0, math.MaxInt8}, eq)
+	roundTrip(t, []uint8{0, 1, math.MaxUint8}, eq)
+	roundTrip(t, []int16{math.MinInt16, -1, math.MaxInt16}, eq)
+	roundTrip(t, []uint16{0, math.MaxUint16}, eq)
+	roundTrip(t, []int32{math.MinInt32, -1, math.MaxInt32}, eq)
+	roundTrip(t, []uint32{0, math.MaxUint32}, eq)
+	roundTrip(t, []int64{math.MinInt64, -1, math.MaxInt64}, eq)
+	roundTrip(t, []uint64{0, math.MaxUint64}, eq)
+	roundTrip(t, []int{math.MinInt, -1, math.MaxInt}, eq)
+	roundTrip(t, []uint{0, math.MaxUint}, eq)
+	roundTrip(t, []float32{-1.5, 0, math.MaxFloat32}, eq)
+	roundTrip(t, []float64{-1.5, math.Inf(1), math.SmallestNonzeroFloat64}, eq)
+	roundTrip(t, []string{"", "a", "héllo"}, eq)
+	roundTrip(t, [][]byte{{}, {1, 2}, {3}}, bytes.Equal)
+	roundTrip(t, []time.Time{time.Unix(0, 0).UTC(), time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)}, time.Time.Equal)
+	roundTrip(t, []int{}, eq)
+}
+
+func TestSeq_FixedWidthLayout(t *testing.T) {
+	data, err := AppendSeq(nil, []int32{1, -1})
+	if err != nil {
+		t.Fatalf("AppendSeq(): unexpected error %v", err)
+	}
+	want := []byte{version, kindSeq, 2, 4, 1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}
+	if !bytes.Equal(data, want) {
+		t.Errorf("AppendSeq(): expected % x, got % x", want, data)
+	}
+
+	data, _ = AppendSeq(nil, []string{"ab", ""})
+	want = []byte{version, kindSeq, 2, prefixed, 2, 'a', 'b', 0}
+	if !bytes.Equal(data, want) {
+		t.Errorf("AppendSeq(): expected % x, got % x", want, data)
+	}
+}
+
+func TestMap_RoundTrip(t *testing.T) {
+	keys := []string{"a", "b", "c"}
+	values := []float64{1, 2.5, -3}
+	data, err := AppendMap(nil, keys, values)
+	if err != nil {
+		t.Fatalf("AppendMap(): unexpected error %v", err)
+	}
+	gotKeys, gotValues, err := DecodeMap[string, float64](data)
+	if err != nil {
+		t.Fatalf("DecodeMap(): unexpected error %v", err)
+	}
+	if !slices.Equal(gotKeys, keys) || !slices.Equal(gotValues, values) {
+		t.Errorf("DecodeMap(): expected %v %v, got %v %v", keys, values, gotKeys, gotValues)
+	}
+
+	// A map cannot be decoded as a sequence and vice versa
+	if _, err := DecodeSeq[string](data); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("DecodeSeq() of a map: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	data, _ := AppendSeq(nil, []int64{1, 2, 3})
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, codec.ErrCorrupt},
+		{"future version", append([]byte{version + 1}, data[1:]...), codec.ErrVersion},
+		{"truncated", data[:len(data)-1], codec.ErrCorrupt},
+		{"trailing bytes", append(slices.Clone(data), 0), codec.ErrCorrupt},
+		{"huge length", []byte{version, kindSeq, 0xff, 0xff, 0xff, 0x7f, 8}, codec.ErrCorrupt},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeSeq[int64](tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	// Elements written with a different width are rejected
+	if _, err := DecodeSeq[int32](data); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("DecodeSeq[int32]() of int64 data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+	if _, err := DecodeSeq[string](data); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("DecodeSeq[string]() of int64 data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+}
+
+type noCodec struct{ a, b int }
+
+type celsius float64
+
+func TestCodec(t *testing.T) {
+	if _, err := AppendSeq(nil, []noCodec{{1, 2}}); !errors.Is(err, codec.ErrNoCodec) {
+		t.Errorf("AppendSeq() without a codec: expected %v, got %v", codec.ErrNoCodec, err)
+	}
+	if _, err := DecodeSeq[noCodec]([]byte{version, kindSeq, 0, prefixed}); !errors.Is(err, codec.ErrNoCodec) {
+		t.Errorf("DecodeSeq() without a codec: expected %v, got %v", codec.ErrNoCodec, err)
+	}
+
+	codec.Register[celsius](codec.Funcs[celsius]{
+		Append: func(b []byte, v celsius) ([]byte, error) {
+			return strconv.AppendFloat(b, float64(v), 'g', -1, 64), nil
+		},
+		Decode: func(data []byte) (celsius, error) {
+			f, err := strconv.ParseFloat(string(data), 64)
+			return celsius(f), err
+		},
+	})
+	roundTrip(t, []celsius{-40, 21.5}, eq)
+
+	data, _ := AppendSeq(nil, []celsius{21.5})
+	want := []byte{version, kindSeq, 1, prefixed, 4, '2', '1', '.', '5'}
+	if !bytes.Equal(data, want) {
+		t.Errorf("AppendSeq() with a codec: expected % x, got % x", want, data)
+	}
+
+	// Errors from the codec are returned unchanged
+	data[len(data)-1] = 'x'
+	if _, err := DecodeSeq[celsius](data); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("DecodeSeq() with a failing codec: expected %v, got %v", strconv.ErrSyntax, err)
+	}
+}
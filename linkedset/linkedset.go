@@ -0,0 +1,309 @@
+package linkedset
+
+import "iter"
+
+// LinkedSet is a generic, non-thread-safe set that remembers the order in
+// which its elements were added. Unlike collections/set, whose iteration
+// order is random, it iterates in insertion order, so its output is
+// deterministic. Adding an element that is already present keeps its
+// position; MoveToFront and MoveToBack change it explicitly.
+//
+// It is backed by a map[T]*entry[T] plus an intrusive doubly linked list of
+// the entries, so Add, Remove, Contains, First, Last, MoveToFront and
+// MoveToBack all take O(1) time.
+//
+// The zero value of LinkedSet[T] is ready to use without initialization.
+// Use New() or NewWithCapacity() to explicitly create a set or provide an
+// initial capacity.
+type LinkedSet[T comparable] struct {
+	items           map[T]*entry[T]
+	root            entry[T] // sentinel: root.next is the first element, root.prev the last
+	initialCapacity int
+	stamps          uint64 // last stamp handed out to an entry
+	iterating       int    // number of iterations in progress
+}
+
+// entry is a node of the insertion order list. A removed entry keeps its
+// links, so that an ongoing iteration positioned on it can move past it.
+type entry[T comparable] struct {
+	value      T
+	prev, next *entry[T]
+	stamp      uint64 // entries created after an iteration started have a greater stamp
+	removed    bool
+}
+
+// New creates an empty set of type T with no pre-allocated capacity.
+// Equivalent to declaring `var s linkedset.LinkedSet[int]`.
+func New[T comparable]() *LinkedSet[T] {
+	return NewWithCapacity[T](0)
+}
+
+// NewWithCapacity creates an empty set with a capacity hint for the underlying map.
+// Useful when you know approximately how many elements the set will contain.
+func NewWithCapacity[T comparable](capacity int) *LinkedSet[T] {
+	s := &LinkedSet[T]{initialCapacity: capacity}
+	s.lazyInit()
+	return s
+}
+
+// FromSeq creates a set holding the values of seq in the order they are
+// produced. Duplicates are ignored and keep the position of their first
+// occurrence.
+func FromSeq[T comparable](seq iter.Seq[T]) *LinkedSet[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add appends a value to the end of the set. If the value already exists,
+// it does nothing and the value keeps its position.
+// Initializes the underlying map if it is nil.
+func (s *LinkedSet[T]) Add(value T) {
+	s.lazyInit()
+	if _, exists := s.items[value]; exists {
+		return
+	}
+	s.insertBefore(s.newEntry(value), &s.root)
+}
+
+// AddMany appends multiple values to the end of the set in order.
+// Duplicates are ignored. Initializes the underlying map if it is nil,
+// sizing it to hold all values.
+func (s *LinkedSet[T]) AddMany(values ...T) {
+	if s.items == nil {
+		s.items = make(map[T]*entry[T], max(s.initialCapacity, len(values)))
+	}
+	for _, v := range values {
+		s.Add(v)
+	}
+}
+
+// Remove deletes a value from the set if it exists. Safe on a zero-value LinkedSet.
+func (s *LinkedSet[T]) Remove(value T) {
+	e, exists := s.items[value]
+	if !exists {
+		return
+	}
+	delete(s.items, value)
+	s.unlink(e)
+	e.removed = true
+}
+
+// Contains reports whether a value exists in the set.
+// Safe to call on a zero-value LinkedSet; returns false without allocating.
+func (s *LinkedSet[T]) Contains(value T) bool {
+	_, exists := s.items[value]
+	return exists
+}
+
+// Len returns the number of elements in the set.
+// Safe to call on a zero-value LinkedSet; returns 0 without allocating.
+func (s *LinkedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// First returns the element at the front of the set, which is the oldest
+// one unless elements were moved. The boolean return is false if the set
+// is empty.
+func (s *LinkedSet[T]) First() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.root.next.value, true
+}
+
+// Last returns the element at the back of the set, which is the most
+// recently added one unless elements were moved. The boolean return is
+// false if the set is empty.
+func (s *LinkedSet[T]) Last() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.root.prev.value, true
+}
+
+// MoveToFront moves value to the front of the set. It reports false,
+// leaving the set unchanged, if value is not in the set.
+func (s *LinkedSet[T]) MoveToFront(value T) bool {
+	e, exists := s.items[value]
+	if !exists {
+		return false
+	}
+	if s.root.next != e {
+		s.insertBefore(s.detach(e), s.root.next)
+	}
+	return true
+}
+
+// MoveToBack moves value to the back of the set, as if it had just been
+// added. It reports false, leaving the set unchanged, if value is not in
+// the set.
+func (s *LinkedSet[T]) MoveToBack(value T) bool {
+	e, exists := s.items[value]
+	if !exists {
+		return false
+	}
+	if s.root.prev != e {
+		s.insertBefore(s.detach(e), &s.root)
+	}
+	return true
+}
+
+// Reset removes all elements from the set but retains the underlying map capacity.
+// Initializes the map if it is nil.
+func (s *LinkedSet[T]) Reset() {
+	s.lazyInit()
+	s.detachAll()
+	clear(s.items)
+}
+
+// Clear removes all elements and resets the underlying map to the initial capacity.
+// Always allocates a new map.
+func (s *LinkedSet[T]) Clear() {
+	s.detachAll()
+	s.items = make(map[T]*entry[T], s.initialCapacity)
+	s.root.next = &s.root
+	s.root.prev = &s.root
+}
+
+// All returns an iterator over the elements of the set from front to back,
+// which is insertion order unless elements were moved.
+//
+// The set may be modified during iteration. Elements removed before they
+// are reached are not produced, and elements added or moved during
+// iteration are not produced from their new position, so every element is
+// produced at most once and iteration always ends.
+// Safe to call on a zero-value LinkedSet.
+//
+//	for v := range s.All() { fmt.Println(v) }
+func (s *LinkedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root.next == nil {
+			return
+		}
+		s.iterating++
+		defer func() { s.iterating-- }()
+		start := s.stamps
+		for e := s.following(&s.root, start); e != &s.root; e = s.following(e, start) {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements of the set from back to
+// front. The set may be modified during iteration, with the same effects
+// as for All.
+// Safe to call on a zero-value LinkedSet.
+func (s *LinkedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root.prev == nil {
+			return
+		}
+		s.iterating++
+		defer func() { s.iterating-- }()
+		start := s.stamps
+		for e := s.preceding(&s.root, start); e != &s.root; e = s.preceding(e, start) {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a copy of the elements of the set from front to back.
+func (s *LinkedSet[T]) Values() []T {
+	values := make([]T, 0, len(s.items))
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// lazyInit prepares a zero-value set for use.
+func (s *LinkedSet[T]) lazyInit() {
+	if s.items == nil {
+		s.items = make(map[T]*entry[T], s.initialCapacity)
+	}
+	if s.root.next == nil {
+		s.root.next = &s.root
+		s.root.prev = &s.root
+	}
+}
+
+// newEntry creates the entry of value and adds it to the map.
+func (s *LinkedSet[T]) newEntry(value T) *entry[T] {
+	s.stamps++
+	e := &entry[T]{value: value, stamp: s.stamps}
+	s.items[value] = e
+	return e
+}
+
+// detach unlinks e so that it can be inserted elsewhere and returns the
+// entry to insert. While an iteration is in progress, e is left in place
+// as a removed entry, so that an iteration positioned on it keeps its
+// place, and a new entry takes over its value.
+func (s *LinkedSet[T]) detach(e *entry[T]) *entry[T] {
+	s.unlink(e)
+	if s.iterating == 0 {
+		return e
+	}
+	e.removed = true
+	return s.newEntry(e.value)
+}
+
+// insertBefore links e into the list in front of mark.
+func (s *LinkedSet[T]) insertBefore(e, mark *entry[T]) {
+	e.prev = mark.prev
+	e.next = mark
+	mark.prev.next = e
+	mark.prev = e
+}
+
+// unlink removes e from the list. e keeps its own links, which point to
+// its neighbours at the time, so that an iteration positioned on it can
+// continue from there.
+func (s *LinkedSet[T]) unlink(e *entry[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+}
+
+// following returns the entry after e for an iteration that started at
+// stamp start, skipping entries removed since it reached them and entries
+// created after it started.
+func (s *LinkedSet[T]) following(e *entry[T], start uint64) *entry[T] {
+	next := e.next
+	for next != &s.root && (next.removed || next.stamp > start) {
+		next = next.next
+	}
+	return next
+}
+
+// preceding returns the entry before e for an iteration that started at
+// stamp start, skipping entries removed since it reached them and entries
+// created after it started.
+func (s *LinkedSet[T]) preceding(e *entry[T], start uint64) *entry[T] {
+	prev := e.prev
+	for prev != &s.root && (prev.removed || prev.stamp > start) {
+		prev = prev.prev
+	}
+	return prev
+}
+
+// detachAll marks every entry as removed, so that an ongoing iteration
+// stops at the sentinel instead of producing them.
+func (s *LinkedSet[T]) detachAll() {
+	if s.root.next == nil {
+		return
+	}
+	for e := s.root.next; e != &s.root; e = e.next {
+		e.removed = true
+	}
+	s.root.next = &s.root
+	s.root.prev = &s.root
+}
@@ -0,0 +1,66 @@
+package linkedset
+
+import (
+	"runtime"
+	"testing"
+)
+
+func BenchmarkLinkedSet_Add(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(i)
+	}
+}
+
+func BenchmarkLinkedSet_Add_PreSized(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewWithCapacity[int](b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(i)
+	}
+}
+
+func BenchmarkLinkedSet_Contains(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(i % 1000)
+	}
+}
+
+func BenchmarkLinkedSet_MoveToFront(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.MoveToFront(i % 1000)
+	}
+}
+
+func BenchmarkLinkedSet_All(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range s.All() {
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package linkedset_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/khavishbhundoo/collections/linkedset"
+)
+
+func ExampleLinkedSet() {
+	s := linkedset.New[string]()
+	s.AddMany("banana", "apple", "cherry")
+
+	// Duplicates keep the position of their first occurrence
+	s.Add("apple")
+	fmt.Println(s.Values())
+
+	first, _ := s.First()
+	last, _ := s.Last()
+	fmt.Println(first, last)
+
+	// Recently used files: touching one moves it to the front
+	s.MoveToFront("cherry")
+	s.MoveToBack("banana")
+	fmt.Println(slices.Collect(s.All()))
+
+	s.Remove("apple")
+	fmt.Println(s.Len(), s.Contains("apple"))
+
+	// Output:
+	// [banana apple cherry]
+	// banana cherry
+	// [cherry apple banana]
+	// 2 false
+}
@@ -0,0 +1,284 @@
+package linkedset
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestLinkedSet_New(t *testing.T) {
+	s := New[int]()
+	if s == nil {
+		t.Fatal("Expected non-nil LinkedSet")
+	}
+	if s.Len() != 0 {
+		t.Errorf("Expected size 0, got %d", s.Len())
+	}
+
+	s = NewWithCapacity[int](10)
+	s.AddMany(1, 2, 3)
+	if s.Len() != 3 {
+		t.Errorf("Expected size 3, got %d", s.Len())
+	}
+}
+
+func TestLinkedSet_AddKeepsOrder(t *testing.T) {
+	s := New[string]()
+	s.AddMany("c", "a", "b")
+	s.Add("a") // already present: keeps its position
+	s.Add("d")
+	if got, want := s.Values(), []string{"c", "a", "b", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Values(): expected %v, got %v", want, got)
+	}
+	if got, want := slices.Collect(s.Backward()), []string{"d", "b", "a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Backward(): expected %v, got %v", want, got)
+	}
+	if !s.Contains("b") || s.Contains("x") {
+		t.Errorf("Contains(): expected true for b and false for x")
+	}
+}
+
+func TestLinkedSet_Remove(t *testing.T) {
+	s := FromSeq(slices.Values([]int{1, 2, 3, 2, 4}))
+	s.Remove(2)
+	s.Remove(10) // not present
+	if got, want := s.Values(), []int{1, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("Values(): expected %v, got %v", want, got)
+	}
+	s.Add(2) // re-added at the back
+	if got, want := s.Values(), []int{1, 3, 4, 2}; !slices.Equal(got, want) {
+		t.Errorf("Values() after re-adding: expected %v, got %v", want, got)
+	}
+	s.Remove(1)
+	s.Remove(2)
+	if first, _ := s.First(); first != 3 {
+		t.Errorf("First(): expected %d, got %d", 3, first)
+	}
+	if last, _ := s.Last(); last != 4 {
+		t.Errorf("Last(): expected %d, got %d", 4, last)
+	}
+}
+
+func TestLinkedSet_FirstLast(t *testing.T) {
+	s := New[int]()
+	if _, ok := s.First(); ok {
+		t.Errorf("First() on empty set: expected NOK, got OK")
+	}
+	if _, ok := s.Last(); ok {
+		t.Errorf("Last() on empty set: expected NOK, got OK")
+	}
+	s.AddMany(5, 6, 7)
+	if v, ok := s.First(); !ok || v != 5 {
+		t.Errorf("First(): expected %d, got %d", 5, v)
+	}
+	if v, ok := s.Last(); !ok || v != 7 {
+		t.Errorf("Last(): expected %d, got %d", 7, v)
+	}
+}
+
+func TestLinkedSet_Move(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3, 4)
+	tests := []struct {
+		name string
+		op   func() bool
+		want []int
+	}{
+		{"MoveToFront(3)", func() bool { return s.MoveToFront(3) }, []int{3, 1, 2, 4}},
+		{"MoveToFront(3) again", func() bool { return s.MoveToFront(3) }, []int{3, 1, 2, 4}},
+		{"MoveToBack(1)", func() bool { return s.MoveToBack(1) }, []int{3, 2, 4, 1}},
+		{"MoveToBack(1) again", func() bool { return s.MoveToBack(1) }, []int{3, 2, 4, 1}},
+		{"MoveToFront(1)", func() bool { return s.MoveToFront(1) }, []int{1, 3, 2, 4}},
+		{"MoveToBack(3)", func() bool { return s.MoveToBack(3) }, []int{1, 2, 4, 3}},
+	}
+	for _, tt := range tests {
+		if !tt.op() {
+			t.Errorf("%s: expected true, got false", tt.name)
+		}
+		if got := s.Values(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+	if s.MoveToFront(10) || s.MoveToBack(10) {
+		t.Errorf("Moving a missing value: expected false, got true")
+	}
+	if got, want := slices.Collect(s.Backward()), []int{3, 4, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("Backward(): expected %v, got %v", want, got)
+	}
+}
+
+func TestLinkedSet_ResetClear(t *testing.T) {
+	s := NewWithCapacity[int](4)
+	s.AddMany(1, 2, 3)
+	s.Reset()
+	if s.Len() != 0 || len(s.Values()) != 0 {
+		t.Errorf("Reset(): expected an empty set, got %v", s.Values())
+	}
+	s.AddMany(4, 5)
+	if got, want := s.Values(), []int{4, 5}; !slices.Equal(got, want) {
+		t.Errorf("Values() after Reset(): expected %v, got %v", want, got)
+	}
+	s.Clear()
+	if s.Len() != 0 || len(s.Values()) != 0 {
+		t.Errorf("Clear(): expected an empty set, got %v", s.Values())
+	}
+	s.Add(6)
+	if got, want := s.Values(), []int{6}; !slices.Equal(got, want) {
+		t.Errorf("Values() after Clear(): expected %v, got %v", want, got)
+	}
+}
+
+func TestLinkedSet_ZeroValue(t *testing.T) {
+	var s LinkedSet[int]
+	if s.Contains(1) || s.Len() != 0 {
+		t.Errorf("Zero value: expected an empty set")
+	}
+	if _, ok := s.First(); ok {
+		t.Errorf("First() on zero value: expected NOK, got OK")
+	}
+	if s.MoveToFront(1) || s.MoveToBack(1) {
+		t.Errorf("Moving on zero value: expected false, got true")
+	}
+	s.Remove(1)
+	for range s.All() {
+		t.Errorf("All() on zero value: expected no elements")
+	}
+	for range s.Backward() {
+		t.Errorf("Backward() on zero value: expected no elements")
+	}
+	s.AddMany(2, 1)
+	if got, want := s.Values(), []int{2, 1}; !slices.Equal(got, want) {
+		t.Errorf("Values(): expected %v, got %v", want, got)
+	}
+
+	var r, c LinkedSet[int]
+	r.Reset()
+	c.Clear()
+	r.Add(1)
+	c.Add(1)
+	if r.Len() != 1 || c.Len() != 1 {
+		t.Errorf("Add() after Reset() or Clear() on zero value: expected size 1")
+	}
+}
+
+func TestLinkedSet_RemoveDuringIteration(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3, 4, 5, 6)
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+		// Remove the current element and the one after it
+		s.Remove(v)
+		s.Remove(v + 1)
+	}
+	if want := []int{1, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("All() while removing: expected %v, got %v", want, got)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, s.Len())
+	}
+
+	s.AddMany(1, 2, 3, 4, 5, 6)
+	got = nil
+	for v := range s.Backward() {
+		got = append(got, v)
+		s.Remove(v)
+		s.Remove(v - 1)
+	}
+	if want := []int{6, 4, 2}; !slices.Equal(got, want) {
+		t.Errorf("Backward() while removing: expected %v, got %v", want, got)
+	}
+
+	s.AddMany(1, 2, 3)
+	got = nil
+	for v := range s.All() {
+		got = append(got, v)
+		s.Clear()
+	}
+	if want := []int{1}; !slices.Equal(got, want) {
+		t.Errorf("All() while clearing: expected %v, got %v", want, got)
+	}
+}
+
+func TestLinkedSet_EarlyBreak(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+	for v := range s.All() {
+		if v == 2 {
+			break
+		}
+	}
+	for v := range s.Backward() {
+		if v == 2 {
+			break
+		}
+	}
+}
+
+func TestLinkedSet_MoveDuringIteration(t *testing.T) {
+	tests := []struct {
+		name     string
+		iterate  func(s *LinkedSet[int]) iter.Seq[int]
+		move     func(s *LinkedSet[int], v int)
+		produced []int
+		after    []int
+	}{
+		{"All/MoveToFront", (*LinkedSet[int]).All, func(s *LinkedSet[int], v int) { s.MoveToFront(v) }, []int{0, 1, 2, 3}, []int{3, 2, 1, 0}},
+		{"All/MoveToBack", (*LinkedSet[int]).All, func(s *LinkedSet[int], v int) { s.MoveToBack(v) }, []int{0, 1, 2, 3}, []int{0, 1, 2, 3}},
+		{"All/MoveToFront next", (*LinkedSet[int]).All, func(s *LinkedSet[int], v int) { s.MoveToFront(v + 1) }, []int{0, 2}, []int{3, 1, 0, 2}},
+		{"Backward/MoveToBack", (*LinkedSet[int]).Backward, func(s *LinkedSet[int], v int) { s.MoveToBack(v) }, []int{3, 2, 1, 0}, []int{3, 2, 1, 0}},
+		{"Backward/MoveToFront", (*LinkedSet[int]).Backward, func(s *LinkedSet[int], v int) { s.MoveToFront(v) }, []int{3, 2, 1, 0}, []int{0, 1, 2, 3}},
+		{"Backward/MoveToBack previous", (*LinkedSet[int]).Backward, func(s *LinkedSet[int], v int) { s.MoveToBack(v - 1) }, []int{3, 1}, []int{1, 3, 2, 0}},
+	}
+	for _, tt := range tests {
+		s := New[int]()
+		s.AddMany(0, 1, 2, 3)
+		var got []int
+		for v := range tt.iterate(s) {
+			got = append(got, v)
+			if len(got) > s.Len() {
+				t.Fatalf("%s: iteration did not end, produced %v", tt.name, got)
+			}
+			tt.move(s, v)
+		}
+		if !slices.Equal(got, tt.produced) {
+			t.Errorf("%s: expected to produce %v, got %v", tt.name, tt.produced, got)
+		}
+		if values := s.Values(); !slices.Equal(values, tt.after) {
+			t.Errorf("%s: expected %v afterwards, got %v", tt.name, tt.after, values)
+		}
+	}
+}
+
+func TestLinkedSet_AddDuringIteration(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+		s.Add(v + 10)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("All() while adding: expected %v, got %v", want, got)
+	}
+	if got, want := s.Values(), []int{1, 2, 3, 11, 12, 13}; !slices.Equal(got, want) {
+		t.Errorf("Values(): expected %v, got %v", want, got)
+	}
+}
+
+func TestLinkedSet_MoveDoesNotAllocate(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+	for v := range s.All() {
+		if v == 2 {
+			break // the finished iteration must not keep moves allocating
+		}
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		s.MoveToFront(3)
+		s.MoveToBack(3)
+	})
+	if allocs != 0 {
+		t.Errorf("MoveToFront() and MoveToBack(): expected no allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,296 @@
+package pqueue
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Indexed is a generic, non-thread-safe priority queue of unique keys,
+// each with a priority that can be changed while the key is queued.
+// It is backed by a binary heap stored in a dynamically resizing slice,
+// plus a map from every key to its position in the heap, so that
+// Update, Remove, Contains and Priority find a key without searching.
+// This makes it suitable for Dijkstra's and A* algorithms, or deadline
+// schedulers, where priorities change after an item was queued.
+//
+// Keys are ordered by their priorities using the less function given at
+// construction: if less(a, b) is true, the key with priority a is popped
+// before the key with priority b.
+//
+// Use NewIndexed() or NewIndexedWithCapacity() with a custom less function,
+// or NewIndexedOrdered() for ordered priorities. Like PQueue, and unlike
+// the other collections, the zero value of Indexed is not ready to use:
+// there is no ordering for an arbitrary P to fall back on, so it can be
+// popped and searched as an empty queue, but Push panics on it.
+type Indexed[K comparable, P any] struct {
+	items           []entry[K, P] // binary heap; the children of i are 2i+1 and 2i+2
+	index           map[K]int     // position of every key in items
+	less            func(a, b P) bool
+	initialCapacity int
+}
+
+// entry is a key queued in an Indexed priority queue with its priority.
+type entry[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// NewIndexed creates an empty indexed priority queue whose priorities are
+// ordered by less, with no pre-allocated capacity.
+// It panics if less is nil.
+func NewIndexed[K comparable, P any](less func(a, b P) bool) *Indexed[K, P] {
+	mustLess(less)
+	return &Indexed[K, P]{
+		items:           []entry[K, P]{},
+		index:           make(map[K]int),
+		less:            less,
+		initialCapacity: 0,
+	}
+}
+
+// NewIndexedWithCapacity creates an empty indexed priority queue whose
+// priorities are ordered by less, with a pre-allocated capacity. This
+// avoids repeated allocations if you know roughly how many keys you’ll push.
+// It panics if less is nil.
+func NewIndexedWithCapacity[K comparable, P any](capacity int, less func(a, b P) bool) *Indexed[K, P] {
+	mustLess(less)
+	return &Indexed[K, P]{
+		items:           make([]entry[K, P], 0, capacity),
+		index:           make(map[K]int, capacity),
+		less:            less,
+		initialCapacity: capacity,
+	}
+}
+
+// NewIndexedOrdered creates an empty indexed priority queue with ordered
+// priorities, which pops the key with the smallest priority first.
+func NewIndexedOrdered[K comparable, P cmp.Ordered]() *Indexed[K, P] {
+	return NewIndexed[K](cmp.Less[P])
+}
+
+// Push adds key to the queue with the given priority and reports
+// whether it was added. If key is already queued, its priority is
+// changed instead, as with Update, and Push returns false.
+// It runs in O(log n) time.
+func (pq *Indexed[K, P]) Push(key K, priority P) bool {
+	if pq.less == nil {
+		panic("pqueue: Push on an Indexed without a less function; use NewIndexed or NewIndexedOrdered")
+	}
+	if i, ok := pq.index[key]; ok {
+		pq.fix(i, priority)
+		return false
+	}
+	if pq.index == nil {
+		pq.index = make(map[K]int, pq.initialCapacity)
+	}
+	pq.items = append(pq.items, entry[K, P]{key: key, priority: priority})
+	pq.index[key] = len(pq.items) - 1
+	pq.up(len(pq.items) - 1)
+	return true
+}
+
+// Update changes the priority of a queued key and reports whether key
+// was in the queue. The priority may move in either direction, so Update
+// also serves as a decrease-key operation. It runs in O(log n) time.
+func (pq *Indexed[K, P]) Update(key K, priority P) bool {
+	i, ok := pq.index[key]
+	if !ok {
+		return false
+	}
+	pq.fix(i, priority)
+	return true
+}
+
+// Remove removes key from the queue and returns its priority.
+// The boolean return is false if key was not in the queue.
+// It runs in O(log n) time.
+func (pq *Indexed[K, P]) Remove(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	e := pq.removeAt(i)
+	return e.priority, true
+}
+
+// Contains reports whether key is in the queue.
+func (pq *Indexed[K, P]) Contains(key K) bool {
+	_, ok := pq.index[key]
+	return ok
+}
+
+// Priority returns the priority of a queued key.
+// The boolean return is false if key is not in the queue.
+func (pq *Indexed[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return pq.items[i].priority, true
+}
+
+// Pop removes and returns the key with the highest priority, along with
+// that priority. The boolean return is false if the queue is empty.
+// The queue may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (pq *Indexed[K, P]) Pop() (K, P, bool) {
+	if len(pq.items) == 0 {
+		var key K
+		var priority P
+		return key, priority, false
+	}
+	e := pq.removeAt(0)
+	return e.key, e.priority, true
+}
+
+// Peek returns the key with the highest priority and its priority
+// without removing it. The boolean return is false if the queue is empty.
+func (pq *Indexed[K, P]) Peek() (K, P, bool) {
+	if len(pq.items) == 0 {
+		var key K
+		var priority P
+		return key, priority, false
+	}
+	return pq.items[0].key, pq.items[0].priority, true
+}
+
+// Len returns the current number of keys in the queue.
+func (pq *Indexed[K, P]) Len() int {
+	return len(pq.items)
+}
+
+// Reset clears all keys but keeps the current capacity
+// of the underlying slice and map. This is faster than Clear()
+// when you expect to reuse the same queue size.
+func (pq *Indexed[K, P]) Reset() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+	clear(pq.index)
+}
+
+// Clear removes all keys and reallocates a slice and map with
+// the initial capacity (if any). Use this to shrink the
+// backing storage explicitly.
+func (pq *Indexed[K, P]) Clear() {
+	pq.items = make([]entry[K, P], 0, pq.initialCapacity)
+	pq.index = make(map[K]int, pq.initialCapacity)
+}
+
+// All returns an iterator over the keys of the queue and their priorities
+// in priority order, the order in which Pop would return them, without
+// removing them. It sorts a copy of the entries when iteration starts,
+// so it costs O(n log n) time and O(n) memory.
+func (pq *Indexed[K, P]) All() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		items := slices.Clone(pq.items)
+		slices.SortFunc(items, func(a, b entry[K, P]) int {
+			switch {
+			case pq.less(a.priority, b.priority):
+				return -1
+			case pq.less(b.priority, a.priority):
+				return 1
+			default:
+				return 0
+			}
+		})
+		for _, e := range items {
+			if !yield(e.key, e.priority) {
+				return
+			}
+		}
+	}
+}
+
+// fix sets the priority of the entry at index i and restores the heap
+// invariant by moving the entry up or down as needed.
+func (pq *Indexed[K, P]) fix(i int, priority P) {
+	pq.items[i].priority = priority
+	if !pq.up(i) {
+		pq.down(i)
+	}
+}
+
+// removeAt removes and returns the entry at index i, moving the last
+// entry into its place, and shrinks the slice when appropriate.
+func (pq *Indexed[K, P]) removeAt(i int) entry[K, P] {
+	last := len(pq.items) - 1
+	e := pq.items[i]
+	delete(pq.index, e.key)
+	if i != last {
+		pq.items[i] = pq.items[last]
+		pq.index[pq.items[i].key] = i
+	}
+	pq.items[last] = entry[K, P]{} // release the references for the GC
+	pq.items = pq.items[:last]
+	if i != last && !pq.up(i) {
+		pq.down(i)
+	}
+
+	// Reduce capacity using the same policy as PQueue.Pop: only when the
+	// slice is past the shrink threshold, more than twice the initial
+	// capacity (if any), and less than 1/8 full, halving it each time.
+	capNow := cap(pq.items)
+	if capNow > shrinkCapacityThreshold &&
+		(pq.initialCapacity == 0 || capNow > pq.initialCapacity*2) &&
+		len(pq.items) < capNow/8 {
+
+		newCap := capNow / 2
+		if pq.initialCapacity > 0 && newCap < pq.initialCapacity {
+			newCap = pq.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			newItems := make([]entry[K, P], len(pq.items), newCap)
+			copy(newItems, pq.items)
+			pq.items = newItems
+		}
+	}
+
+	return e
+}
+
+// up moves the entry at index i towards the root until its parent does
+// not have a lower priority, keeping the index map in sync. It reports
+// whether the entry moved.
+func (pq *Indexed[K, P]) up(i int) bool {
+	start := i
+	e := pq.items[i]
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(e.priority, pq.items[parent].priority) {
+			break
+		}
+		pq.items[i] = pq.items[parent]
+		pq.index[pq.items[i].key] = i
+		i = parent
+	}
+	pq.items[i] = e
+	pq.index[e.key] = i
+	return i != start
+}
+
+// down moves the entry at index i towards the leaves until none of its
+// children has a higher priority, keeping the index map in sync.
+func (pq *Indexed[K, P]) down(i int) {
+	n := len(pq.items)
+	e := pq.items[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right].priority, pq.items[child].priority) {
+			child = right
+		}
+		if !pq.less(pq.items[child].priority, e.priority) {
+			break
+		}
+		pq.items[i] = pq.items[child]
+		pq.index[pq.items[i].key] = i
+		i = child
+	}
+	pq.items[i] = e
+	pq.index[e.key] = i
+}
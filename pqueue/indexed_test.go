@@ -0,0 +1,265 @@
+package pqueue
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkIndexed verifies the heap invariant and that the index map
+// points at the position of every key.
+func checkIndexed[K comparable, P any](t *testing.T, pq *Indexed[K, P]) {
+	t.Helper()
+	if len(pq.index) != len(pq.items) {
+		t.Fatalf("index has %d keys, heap has %d entries", len(pq.index), len(pq.items))
+	}
+	for i, e := range pq.items {
+		if pq.index[e.key] != i {
+			t.Fatalf("index[%v] = %d, want %d", e.key, pq.index[e.key], i)
+		}
+		if i > 0 && pq.less(e.priority, pq.items[(i-1)/2].priority) {
+			t.Fatalf("heap invariant violated at %d", i)
+		}
+	}
+}
+
+func TestIndexed_PushPop(t *testing.T) {
+	pq := NewIndexedOrdered[string, int]()
+	if !pq.Push("c", 3) || !pq.Push("a", 1) || !pq.Push("b", 2) {
+		t.Errorf("Push() of a new key: expected true, got false")
+	}
+	checkIndexed(t, pq)
+
+	if k, p, ok := pq.Peek(); !ok || k != "a" || p != 1 {
+		t.Errorf("Peek(): expected (a, 1), got (%s, %d)", k, p)
+	}
+	for i, key := range []string{"a", "b", "c"} {
+		k, p, ok := pq.Pop()
+		if !ok || k != key || p != i+1 {
+			t.Errorf("Pop(): expected (%s, %d), got (%s, %d)", key, i+1, k, p)
+		}
+		checkIndexed(t, pq)
+	}
+	if _, _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() on empty queue: expected NOK, got OK")
+	}
+	if _, _, ok := pq.Peek(); ok {
+		t.Errorf("Peek() on empty queue: expected NOK, got OK")
+	}
+}
+
+func TestIndexed_PushExistingKey(t *testing.T) {
+	pq := NewIndexedOrdered[string, int]()
+	pq.Push("a", 5)
+	pq.Push("b", 3)
+	if pq.Push("a", 1) {
+		t.Errorf("Push() of a queued key: expected false, got true")
+	}
+	if pq.Len() != 2 {
+		t.Errorf("Len(): expected %d, got %d", 2, pq.Len())
+	}
+	if k, _, _ := pq.Peek(); k != "a" {
+		t.Errorf("Peek(): expected %q, got %q", "a", k)
+	}
+}
+
+func TestIndexed_Update(t *testing.T) {
+	pq := NewIndexedOrdered[int, int]()
+	for i := 0; i < 10; i++ {
+		pq.Push(i, i*10)
+	}
+
+	// Decrease a key to the front, then increase the front to the back
+	if !pq.Update(7, -1) {
+		t.Errorf("Update() of a queued key: expected true, got false")
+	}
+	checkIndexed(t, pq)
+	if k, p, _ := pq.Peek(); k != 7 || p != -1 {
+		t.Errorf("Peek() after decrease: expected (7, -1), got (%d, %d)", k, p)
+	}
+	pq.Update(7, 1000)
+	checkIndexed(t, pq)
+	if k, _, _ := pq.Peek(); k != 0 {
+		t.Errorf("Peek() after increase: expected %d, got %d", 0, k)
+	}
+	if p, ok := pq.Priority(7); !ok || p != 1000 {
+		t.Errorf("Priority(7): expected %d, got %d", 1000, p)
+	}
+
+	if pq.Update(42, 0) {
+		t.Errorf("Update() of a missing key: expected false, got true")
+	}
+	if pq.Contains(42) {
+		t.Errorf("Update() of a missing key must not add it")
+	}
+}
+
+func TestIndexed_Remove(t *testing.T) {
+	pq := NewIndexedOrdered[int, int]()
+	for i := 0; i < 10; i++ {
+		pq.Push(i, i)
+	}
+
+	p, ok := pq.Remove(4)
+	if !ok || p != 4 {
+		t.Errorf("Remove(4): expected %d, got %d", 4, p)
+	}
+	checkIndexed(t, pq)
+	if pq.Contains(4) {
+		t.Errorf("Contains(4) after Remove(): expected false, got true")
+	}
+	if _, ok := pq.Remove(4); ok {
+		t.Errorf("Remove() of a missing key: expected NOK, got OK")
+	}
+
+	var got []int
+	for pq.Len() > 0 {
+		k, _, _ := pq.Pop()
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{0, 1, 2, 3, 5, 6, 7, 8, 9}) {
+		t.Errorf("Pop order: expected %v, got %v", []int{0, 1, 2, 3, 5, 6, 7, 8, 9}, got)
+	}
+}
+
+func TestIndexed_RandomOps(t *testing.T) {
+	pq := NewIndexedOrdered[int, int]()
+	model := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := rand.IntN(200)
+		switch rand.IntN(4) {
+		case 0:
+			pq.Push(key, rand.IntN(1000))
+			model[key], _ = pq.Priority(key)
+		case 1:
+			p := rand.IntN(1000)
+			if pq.Update(key, p) {
+				model[key] = p
+			}
+		case 2:
+			if _, ok := pq.Remove(key); ok {
+				delete(model, key)
+			}
+		case 3:
+			k, p, ok := pq.Pop()
+			if ok {
+				for _, mp := range model {
+					if mp < p {
+						t.Fatalf("Pop() returned priority %d while %d was queued", p, mp)
+					}
+				}
+				delete(model, k)
+			}
+		}
+	}
+	checkIndexed(t, pq)
+	if pq.Len() != len(model) {
+		t.Errorf("Len(): expected %d, got %d", len(model), pq.Len())
+	}
+	for k, mp := range model {
+		if p, ok := pq.Priority(k); !ok || p != mp {
+			t.Errorf("Priority(%d): expected %d, got %d", k, mp, p)
+		}
+	}
+}
+
+func TestIndexed_NilLess(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"NewIndexed(nil)":                func() { NewIndexed[string, int](nil) },
+		"NewIndexedWithCapacity(8, nil)": func() { NewIndexedWithCapacity[string, int](8, nil) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "pqueue: nil less function" {
+					t.Errorf("%s: expected a panic about the nil less function, got %v", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestIndexed_ZeroValue(t *testing.T) {
+	var pq Indexed[string, int]
+	if _, _, ok := pq.Pop(); ok {
+		t.Errorf("Pop() on zero value: expected NOK, got OK")
+	}
+	if pq.Contains("a") || pq.Update("a", 1) {
+		t.Errorf("Zero value must be empty")
+	}
+	if _, ok := pq.Remove("a"); ok {
+		t.Errorf("Remove() on zero value: expected NOK, got OK")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Push() on zero value: expected panic")
+		}
+	}()
+	pq.Push("a", 1)
+}
+
+func TestIndexed_ResetClear(t *testing.T) {
+	pq := NewIndexedWithCapacity[int](5, cmp.Less[int])
+	pq.Push(1, 1)
+	pq.Push(2, 2)
+
+	pq.Reset()
+	if pq.Len() != 0 || pq.Contains(1) {
+		t.Errorf("Reset(): expected empty queue, got Len() = %d", pq.Len())
+	}
+	if cap(pq.items) != 5 {
+		t.Errorf("Capacity after Reset(): expected %d, got %d", 5, cap(pq.items))
+	}
+
+	for i := 0; i < 10; i++ {
+		pq.Push(i, i)
+	}
+	pq.Clear()
+	if pq.Len() != 0 || pq.Contains(1) {
+		t.Errorf("Clear(): expected empty queue, got Len() = %d", pq.Len())
+	}
+	if cap(pq.items) != 5 {
+		t.Errorf("Capacity after Clear(): expected %d, got %d", 5, cap(pq.items))
+	}
+}
+
+func TestIndexed_Shrink(t *testing.T) {
+	pq := NewIndexedOrdered[int, int]()
+	for i := 0; i < 200; i++ {
+		pq.Push(i, i)
+	}
+	peakCap := cap(pq.items)
+
+	for i := 0; i < 190; i++ {
+		pq.Remove(i)
+	}
+	checkIndexed(t, pq)
+
+	if cap(pq.items) >= peakCap {
+		t.Errorf("Expected capacity to shrink below peak %d, got %d", peakCap, cap(pq.items))
+	}
+	if k, _, _ := pq.Peek(); k != 190 {
+		t.Errorf("Peek(): expected %d, got %d", 190, k)
+	}
+}
+
+func TestIndexed_All(t *testing.T) {
+	pq := NewIndexedOrdered[string, int]()
+	pq.Push("c", 3)
+	pq.Push("a", 1)
+	pq.Push("b", 2)
+
+	var keys []string
+	for k := range pq.All() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("All(): expected %v, got %v", []string{"a", "b", "c"}, keys)
+	}
+	if pq.Len() != 3 {
+		t.Errorf("Len() after iterating: expected %d, got %d", 3, pq.Len())
+	}
+}
@@ -0,0 +1,294 @@
+package pqueue
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// PQueue is a generic, non-thread-safe priority queue implementation
+// backed by a binary heap stored in a dynamically resizing slice.
+// Pop always returns the item with the highest priority, as decided
+// by the less function given at construction: if less(a, b) is true,
+// a is popped before b. Items of equal priority are popped in no
+// particular order.
+//
+// Use New() or NewWithCapacity() with a custom less function, or
+// NewOrdered() and NewOrderedMax() for ordered types. Unlike the other
+// collections, the zero value of PQueue[T] is not ready to use: there is
+// no ordering for an arbitrary T to fall back on, so it can be popped and
+// peeked as an empty queue, but Push panics on it.
+// If you do need thread-safety, use the collections/concurrent/pqueue package instead.
+type PQueue[T any] struct {
+	items           []T // binary heap; the children of i are 2i+1 and 2i+2
+	less            func(a, b T) bool
+	initialCapacity int
+}
+
+// shrinkCapacityThreshold defines the minimum slice capacity before
+// shrink operations are considered. Avoids aggressive shrinking for
+// small queues that would just grow again.
+// shrinkCapacityThreshold is just one parameter when deciding to shrink
+// the underlying array
+const shrinkCapacityThreshold = 16
+
+// New creates an empty priority queue of type T ordered by less,
+// with no pre-allocated capacity. Items for which less reports true
+// are popped first, so cmp.Less gives a min-queue.
+// It panics if less is nil.
+func New[T any](less func(a, b T) bool) *PQueue[T] {
+	mustLess(less)
+	return &PQueue[T]{
+		items:           []T{},
+		less:            less,
+		initialCapacity: 0,
+	}
+}
+
+// NewWithCapacity creates an empty priority queue of type T ordered by
+// less, with a pre-allocated capacity. This avoids repeated allocations
+// if you know roughly how many elements you’ll push.
+// It panics if less is nil.
+func NewWithCapacity[T any](capacity int, less func(a, b T) bool) *PQueue[T] {
+	mustLess(less)
+	return &PQueue[T]{
+		items:           make([]T, 0, capacity),
+		less:            less,
+		initialCapacity: capacity,
+	}
+}
+
+// NewOrdered creates an empty min-priority queue of an ordered type,
+// which pops the smallest item first.
+func NewOrdered[T cmp.Ordered]() *PQueue[T] {
+	return New(cmp.Less[T])
+}
+
+// NewOrderedMax creates an empty max-priority queue of an ordered type,
+// which pops the largest item first.
+func NewOrderedMax[T cmp.Ordered]() *PQueue[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// FromSeq creates a priority queue ordered by less holding the values
+// of seq. The heap is built in linear time once all values are read.
+// It panics if less is nil, before reading seq.
+func FromSeq[T any](seq iter.Seq[T], less func(a, b T) bool) *PQueue[T] {
+	pq := New(less)
+	pq.items = slices.AppendSeq(pq.items, seq)
+	pq.init()
+	return pq
+}
+
+// PushMany pushes one or more items onto the queue.
+// Equivalent to calling Push repeatedly but more efficient
+// when adding many elements to a small queue.
+func (pq *PQueue[T]) PushMany(item ...T) {
+	pq.mustOrder()
+	n := len(pq.items)
+	pq.items = append(pq.items, item...)
+	// Rebuilding the whole heap is linear, which beats sifting
+	// every new item up once they outnumber the existing ones.
+	if len(item) > n {
+		pq.init()
+		return
+	}
+	for i := n; i < len(pq.items); i++ {
+		pq.up(i)
+	}
+}
+
+// Push adds a single item to the queue.
+func (pq *PQueue[T]) Push(item T) {
+	pq.mustOrder()
+	pq.items = append(pq.items, item)
+	pq.up(len(pq.items) - 1)
+}
+
+// Pop removes and returns the item with the highest priority.
+// The boolean return is false if the queue is empty.
+// The queue may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (pq *PQueue[T]) Pop() (T, bool) {
+	var zero T
+	if len(pq.items) == 0 {
+		return zero, false
+	}
+	last := len(pq.items) - 1
+	item := pq.items[0]
+	pq.items[0] = pq.items[last]
+	pq.items[last] = zero // release the reference for the GC
+	pq.items = pq.items[:last]
+	if last > 0 {
+		pq.down(0)
+	}
+
+	// Reduce capacity if:
+	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
+	//   - current capacity exceeds 2× the initial capacity (if any),
+	//   - and fewer than 12.5% of elements are in use (cap/8).
+	//
+	// Why 1/8 instead of 1/4?
+	//   Using 1/4 is fine for general use, but in tight push/pop workloads
+	//   it may trigger frequent grow/shrink oscillations. Using 1/8 shrinks
+	//   only when the queue is significantly underutilized.
+	//
+	// Why halve capacity?
+	//   Halving avoids repeated reallocations while still reclaiming
+	//   unused memory proportionally. It balances memory efficiency and speed.
+	capNow := cap(pq.items)
+	if capNow > shrinkCapacityThreshold &&
+		(pq.initialCapacity == 0 || capNow > pq.initialCapacity*2) &&
+		len(pq.items) < capNow/8 {
+
+		newCap := capNow / 2
+		if pq.initialCapacity > 0 && newCap < pq.initialCapacity {
+			newCap = pq.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			newItems := make([]T, len(pq.items), newCap)
+			copy(newItems, pq.items)
+			pq.items = newItems
+		}
+	}
+
+	return item, true
+}
+
+// Peek returns the item with the highest priority without removing it.
+// The boolean return is false if the queue is empty.
+func (pq *PQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0], true
+}
+
+// Len returns the current number of items in the queue.
+func (pq *PQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying slice. This is faster than Clear()
+// when you expect to reuse the same queue size.
+func (pq *PQueue[T]) Reset() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// Clear removes all items and reallocates a slice with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (pq *PQueue[T]) Clear() {
+	pq.items = make([]T, 0, pq.initialCapacity)
+}
+
+// All returns an iterator over the items of the queue in priority
+// order, the order in which Pop would return them, without removing
+// them. It sorts a copy of the items when iteration starts, so it
+// costs O(n log n) time and O(n) memory.
+func (pq *PQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range pq.sorted() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue in reverse
+// priority order, lowest priority first, without removing them.
+// Like All, it sorts a copy of the items when iteration starts.
+func (pq *PQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(pq.sorted()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// sorted returns a copy of the items in priority order.
+func (pq *PQueue[T]) sorted() []T {
+	items := slices.Clone(pq.items)
+	if len(items) > 1 {
+		slices.SortFunc(items, pq.compare)
+	}
+	return items
+}
+
+// compare adapts less to the three-way comparison used by slices.SortFunc.
+func (pq *PQueue[T]) compare(a, b T) int {
+	switch {
+	case pq.less(a, b):
+		return -1
+	case pq.less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// mustLess panics if less is nil, so that a queue without an ordering
+// fails where it is created rather than on its first Push.
+func mustLess[T any](less func(a, b T) bool) {
+	if less == nil {
+		panic("pqueue: nil less function")
+	}
+}
+
+// mustOrder panics with a helpful message when items are pushed onto
+// a zero-value queue, which has no less function to order them by.
+func (pq *PQueue[T]) mustOrder() {
+	if pq.less == nil {
+		panic("pqueue: Push on a PQueue without a less function; use New or NewOrdered")
+	}
+}
+
+// init establishes the heap invariant over all items in linear time.
+func (pq *PQueue[T]) init() {
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+}
+
+// up moves the item at index i towards the root until its parent
+// does not have a lower priority.
+func (pq *PQueue[T]) up(i int) {
+	item := pq.items[i]
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(item, pq.items[parent]) {
+			break
+		}
+		pq.items[i] = pq.items[parent]
+		i = parent
+	}
+	pq.items[i] = item
+}
+
+// down moves the item at index i towards the leaves until none of its
+// children has a higher priority.
+func (pq *PQueue[T]) down(i int) {
+	n := len(pq.items)
+	item := pq.items[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right], pq.items[child]) {
+			child = right
+		}
+		if !pq.less(pq.items[child], item) {
+			break
+		}
+		pq.items[i] = pq.items[child]
+		i = child
+	}
+	pq.items[i] = item
+}
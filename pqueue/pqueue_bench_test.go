@@ -0,0 +1,106 @@
+package pqueue
+
+import (
+	"cmp"
+	"container/heap"
+	"math/rand/v2"
+	"runtime"
+	"testing"
+)
+
+func BenchmarkPQueue_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for b.Loop() {
+		pq.Push(rand.Int())
+	}
+}
+
+func BenchmarkPQueue_PushWithCapacity(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewWithCapacity(b.N, cmp.Less[int])
+	for b.Loop() {
+		pq.Push(rand.Int())
+	}
+}
+
+func BenchmarkPQueue_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for i := 0; i < b.N; i++ {
+		pq.Push(rand.Int())
+	}
+	for b.Loop() {
+		pq.Pop()
+	}
+}
+
+func BenchmarkPQueue_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewOrdered[int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(rand.Int())
+	}
+	for b.Loop() {
+		pq.Push(rand.Int())
+		pq.Pop()
+	}
+}
+
+// intHeap implements heap.Interface to compare against container/heap.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func BenchmarkContainerHeap_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	h := &intHeap{}
+	for i := 0; i < 1000; i++ {
+		heap.Push(h, rand.Int())
+	}
+	for b.Loop() {
+		heap.Push(h, rand.Int())
+		heap.Pop(h)
+	}
+}
+
+func BenchmarkIndexed_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewIndexedOrdered[int, int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(i, rand.Int())
+	}
+	key := 1000
+	for b.Loop() {
+		pq.Push(key, rand.Int())
+		pq.Pop()
+		key++
+	}
+}
+
+func BenchmarkIndexed_Update(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	pq := NewIndexedOrdered[int, int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(i, rand.Int())
+	}
+	for b.Loop() {
+		pq.Update(rand.IntN(1000), rand.Int())
+	}
+}
@@ -0,0 +1,84 @@
+package pqueue_test
+
+import (
+	"fmt"
+
+	"github.com/khavishbhundoo/collections/pqueue"
+)
+
+func ExamplePQueue() {
+	// NewOrdered pops the smallest item first
+	pq := pqueue.NewOrdered[int]()
+	pq.PushMany(5, 1, 4)
+	pq.Push(2)
+
+	val, ok := pq.Peek()
+	fmt.Println(val, ok)
+	fmt.Println(pq.Len())
+	for pq.Len() > 0 {
+		val, _ = pq.Pop()
+		fmt.Println(val)
+	}
+	val, ok = pq.Pop()
+	fmt.Println(val, ok)
+
+	// Output:
+	// 1 true
+	// 4
+	// 1
+	// 2
+	// 4
+	// 5
+	// 0 false
+}
+
+func ExampleNew() {
+	type job struct {
+		name     string
+		priority int
+	}
+
+	// Jobs with a higher priority are popped first
+	pq := pqueue.New(func(a, b job) bool { return a.priority > b.priority })
+	pq.Push(job{"backup", 1})
+	pq.Push(job{"deploy", 10})
+	pq.Push(job{"report", 5})
+
+	for j := range pq.All() {
+		fmt.Println(j.name)
+	}
+
+	// Output:
+	// deploy
+	// report
+	// backup
+}
+
+func ExampleIndexed() {
+	// Dijkstra's shortest paths, updating the distance of queued nodes
+	graph := map[string]map[string]int{
+		"a": {"b": 4, "c": 1},
+		"c": {"b": 2, "d": 5},
+		"b": {"d": 1},
+	}
+	dist := map[string]int{"a": 0}
+
+	pq := pqueue.NewIndexedOrdered[string, int]()
+	pq.Push("a", 0)
+	for pq.Len() > 0 {
+		node, d, _ := pq.Pop()
+		fmt.Println(node, d)
+		for next, w := range graph[node] {
+			if old, seen := dist[next]; !seen || d+w < old {
+				dist[next] = d + w
+				pq.Push(next, d+w) // adds the node or lowers its priority
+			}
+		}
+	}
+
+	// Output:
+	// a 0
+	// c 1
+	// b 3
+	// d 4
+}
@@ -0,0 +1,36 @@
+package queue
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The items are written
+// in FIFO order, front first, in a compact versioned format.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// MarshalBinary and GobEncode have value receivers so that a Queue embedded
+// by value in another struct is encoded even when it is not addressable.
+func (q Queue[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, q.ordered())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the queue with data encoded by MarshalBinary. On error the
+// queue is not modified.
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	q.replace(items)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (q Queue[T]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (q *Queue[T]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+func TestQueue_Binary(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(0, 1, 2, 3)
+	q.Pop()
+	q.Push(4) // wraps around the ring buffer
+
+	data, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+	decoded := New[int]()
+	decoded.Push(9)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.All()); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("UnmarshalBinary(): expected %v, got %v", []int{1, 2, 3, 4}, got)
+	}
+
+	// Invalid data leaves the queue untouched
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("UnmarshalBinary() of truncated data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+	if decoded.Len() != 4 {
+		t.Errorf("Len(): expected %d, got %d", 4, decoded.Len())
+	}
+}
+
+func TestQueue_Gob(t *testing.T) {
+	type checkpoint struct {
+		Name  string
+		Items Queue[string]
+	}
+	in := checkpoint{Name: "cp", Items: Queue[string]{}}
+	in.Items.PushMany("a", "b", "c")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode(): unexpected error %v", err)
+	}
+	var out checkpoint
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode(): unexpected error %v", err)
+	}
+	if out.Name != "cp" {
+		t.Errorf("Decode(): expected name %q, got %q", "cp", out.Name)
+	}
+	if got := slices.Collect(out.Items.All()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Decode(): expected %v, got %v", []string{"a", "b", "c"}, got)
+	}
+}
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The queue is encoded as a JSON
+// array holding its items in FIFO order, front first. An empty queue
+// encodes as [].
+//
+// MarshalJSON has a value receiver so that a Queue embedded by value in
+// another struct is encoded even when that struct is not addressable.
+func (q Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.ordered())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the queue with the items of a JSON array, the first element becoming the
+// front of the queue. As is conventional, a JSON null leaves the queue
+// unchanged. On error the queue is not modified.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	q.replace(items)
+	return nil
+}
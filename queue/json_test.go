@@ -0,0 +1,83 @@
+package queue
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestQueue_MarshalJSON(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(0, 1, 2, 3)
+	q.Pop()
+	q.Push(4) // wraps around the ring buffer
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != "[1,2,3,4]" {
+		t.Errorf("Marshal(): expected %s, got %s", "[1,2,3,4]", data)
+	}
+
+	var empty Queue[int]
+	data, _ = json.Marshal(&empty)
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty queue: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestQueue_MarshalJSONEmbedded(t *testing.T) {
+	type response struct {
+		Jobs Queue[string] `json:"jobs"`
+	}
+	var r response
+	r.Jobs.PushMany("a", "b")
+
+	// r is passed by value, so the queue is not addressable
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != `{"jobs":["a","b"]}` {
+		t.Errorf("Marshal(): expected %s, got %s", `{"jobs":["a","b"]}`, data)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if got := slices.Collect(decoded.Jobs.All()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Unmarshal(): expected %v, got %v", []string{"a", "b"}, got)
+	}
+}
+
+func TestQueue_UnmarshalJSON(t *testing.T) {
+	q := NewWithCapacity[int](8)
+	q.PushMany(7, 8, 9)
+
+	if err := json.Unmarshal([]byte("[1,2,3]"), q); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Unmarshal(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	if cap(q.items) != 8 {
+		t.Errorf("Capacity after Unmarshal(): expected %d, got %d", 8, cap(q.items))
+	}
+	q.Push(4)
+	if r, _ := q.Pop(); r != 1 {
+		t.Errorf("Pop() after Unmarshal(): expected %d, got %d", 1, r)
+	}
+
+	// null is a no-op and invalid input leaves the queue untouched
+	if err := json.Unmarshal([]byte("null"), q); err != nil {
+		t.Errorf("Unmarshal(null): unexpected error %v", err)
+	}
+	if err := json.Unmarshal([]byte(`["x"]`), q); err == nil {
+		t.Errorf("Unmarshal() of invalid input: expected error, got nil")
+	}
+	if q.Len() != 3 {
+		t.Errorf("Len(): expected %d, got %d", 3, q.Len())
+	}
+}
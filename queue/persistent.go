@@ -0,0 +1,180 @@
+package queue
+
+import (
+	"iter"
+	"slices"
+)
+
+// Persistent is a generic, immutable FIFO queue. Push and Pop leave the
+// queue they are called on unchanged and return a new version instead,
+// which shares its elements with the old one wherever it can.
+// The zero value of Persistent[T] is an empty queue.
+//
+// It is a banker's queue: a front list that Pop takes from and a rear
+// list, in reverse order, that Push adds to. When the rear grows longer
+// than the front, it is reversed onto the end of the front, which copies
+// both. That happens rarely enough for Push and Pop to take amortized O(1)
+// time when each version is used once, as a mutable queue would be; doing
+// the same operation on an old version again may repeat a copy.
+//
+// Since a version never changes, it can be kept as long as needed, and it
+// can be read by any number of goroutines without locking while others
+// derive their own versions. Sharing a variable that holds a version still
+// needs synchronization, such as an atomic.Pointer.
+//
+// Use Queue.Persistent() and Persistent.Queue() to convert to and from
+// the mutable Queue.
+type Persistent[T any] struct {
+	front    *persistentNode[T] // linked from the front of the queue
+	rear     *persistentNode[T] // linked from the back of the queue
+	frontLen int
+	rearLen  int // never more than frontLen
+}
+
+// persistentNode is an element of a Persistent queue. It is never
+// modified once created, so any number of versions can share it.
+type persistentNode[T any] struct {
+	item T
+	next *persistentNode[T]
+}
+
+// PersistentFromSeq creates a persistent queue holding the values of seq
+// in the order they are produced, so the first value yielded is the front
+// of the queue.
+func PersistentFromSeq[T any](seq iter.Seq[T]) Persistent[T] {
+	return fromOrdered(slices.Collect(seq))
+}
+
+// Push returns a queue with item added after the items of p.
+// p itself is not modified.
+func (p Persistent[T]) Push(item T) Persistent[T] {
+	p.rear = &persistentNode[T]{item: item, next: p.rear}
+	p.rearLen++
+	return p.balance()
+}
+
+// PushMany returns a queue with the items added after the items of p,
+// in order. p itself is not modified.
+func (p Persistent[T]) PushMany(item ...T) Persistent[T] {
+	for _, v := range item {
+		p = p.Push(v)
+	}
+	return p
+}
+
+// Pop returns the element in front of p and the queue of the elements
+// behind it. p itself is not modified. The boolean return is false if p
+// is empty, in which case the returned queue is empty too.
+func (p Persistent[T]) Pop() (T, Persistent[T], bool) {
+	if p.front == nil {
+		var zero T
+		return zero, p, false
+	}
+	item := p.front.item
+	p.front = p.front.next
+	p.frontLen--
+	return item, p.balance(), true
+}
+
+// Peek returns the front of the queue.
+// The boolean return is false if the queue is empty.
+func (p Persistent[T]) Peek() (T, bool) {
+	if p.front == nil {
+		var zero T
+		return zero, false
+	}
+	return p.front.item, true
+}
+
+// Len returns the number of items in the queue in O(1) time.
+func (p Persistent[T]) Len() int {
+	return p.frontLen + p.rearLen
+}
+
+// All returns an iterator over the items of the queue in FIFO order,
+// from front to back. It copies the rear list first since it is linked
+// from the back.
+func (p Persistent[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := p.front; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+		for _, v := range slices.Backward(collect(p.rear, p.rearLen)) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue in reverse
+// order, from back to front. It copies the front list first since it is
+// linked from the front.
+func (p Persistent[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := p.rear; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+		for _, v := range slices.Backward(collect(p.front, p.frontLen)) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Queue returns a new mutable queue holding the items of p.
+func (p Persistent[T]) Queue() *Queue[T] {
+	items := collect(p.front, p.Len())
+	items = append(items, collect(p.rear, p.rearLen)...)
+	slices.Reverse(items[p.frontLen:])
+	q := New[T]()
+	q.replace(items)
+	return q
+}
+
+// Persistent returns a persistent queue holding the items of q.
+// Later changes to q do not affect it.
+func (q *Queue[T]) Persistent() Persistent[T] {
+	return fromOrdered(q.ordered())
+}
+
+// balance restores the invariant that the rear list is no longer than
+// the front list by moving the rear, reversed, to the end of the front.
+// The front nodes are copied so that other versions keep theirs.
+func (p Persistent[T]) balance() Persistent[T] {
+	if p.rearLen <= p.frontLen {
+		return p
+	}
+	var front *persistentNode[T]
+	for n := p.rear; n != nil; n = n.next {
+		front = &persistentNode[T]{item: n.item, next: front}
+	}
+	for _, v := range slices.Backward(collect(p.front, p.frontLen)) {
+		front = &persistentNode[T]{item: v, next: front}
+	}
+	return Persistent[T]{front: front, frontLen: p.frontLen + p.rearLen}
+}
+
+// fromOrdered returns a persistent queue holding items in FIFO order.
+func fromOrdered[T any](items []T) Persistent[T] {
+	var front *persistentNode[T]
+	for _, v := range slices.Backward(items) {
+		front = &persistentNode[T]{item: v, next: front}
+	}
+	return Persistent[T]{front: front, frontLen: len(items)}
+}
+
+// collect returns a copy of the items of the list starting at n, in list
+// order. n is a list of length size, used to pre-allocate the copy.
+func collect[T any](n *persistentNode[T], size int) []T {
+	items := make([]T, 0, size)
+	for ; n != nil; n = n.next {
+		items = append(items, n.item)
+	}
+	return items
+}
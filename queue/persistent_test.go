@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistent_PushPop(t *testing.T) {
+	var empty Persistent[int] // zero-value
+	if _, _, ok := empty.Pop(); ok {
+		t.Errorf("Pop() on empty queue: expected NOK, got OK")
+	}
+	if _, ok := empty.Peek(); ok {
+		t.Errorf("Peek() on empty queue: expected NOK, got OK")
+	}
+
+	q1 := empty.Push(1)
+	q2 := q1.PushMany(2, 3)
+	if empty.Len() != 0 || q1.Len() != 1 || q2.Len() != 3 {
+		t.Errorf("Len(): expected 0, 1, 3, got %d, %d, %d", empty.Len(), q1.Len(), q2.Len())
+	}
+	if r, ok := q2.Peek(); !ok || r != 1 {
+		t.Errorf("Peek(): expected %d, got %d", 1, r)
+	}
+
+	r, q3, ok := q2.Pop()
+	if !ok || r != 1 {
+		t.Errorf("Pop(): expected %d, got %d", 1, r)
+	}
+	q4 := q3.Push(4)
+	q5 := q2.Push(5) // branch from an older version
+
+	// Every version keeps its own items
+	tests := []struct {
+		name string
+		q    Persistent[int]
+		want []int
+	}{
+		{"empty", empty, nil},
+		{"q1", q1, []int{1}},
+		{"q2", q2, []int{1, 2, 3}},
+		{"q3", q3, []int{2, 3}},
+		{"q4", q4, []int{2, 3, 4}},
+		{"q5", q5, []int{1, 2, 3, 5}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.q.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s.All(): expected %v, got %v", tt.name, tt.want, got)
+		}
+		want := slices.Clone(tt.want)
+		slices.Reverse(want)
+		if got := slices.Collect(tt.q.Backward()); !slices.Equal(got, want) {
+			t.Errorf("%s.Backward(): expected %v, got %v", tt.name, want, got)
+		}
+		if tt.q.Len() != len(tt.want) {
+			t.Errorf("%s.Len(): expected %d, got %d", tt.name, len(tt.want), tt.q.Len())
+		}
+	}
+}
+
+func TestPersistent_Random(t *testing.T) {
+	// Follow random operations on random versions against slices
+	r := rand.New(rand.NewPCG(1, 2))
+	versions := []Persistent[int]{{}}
+	models := [][]int{nil}
+	for i := 0; i < 5000; i++ {
+		v := r.IntN(len(versions))
+		q, model := versions[v], models[v]
+		if r.IntN(3) == 0 {
+			item, next, ok := q.Pop()
+			if ok != (len(model) > 0) {
+				t.Fatalf("Pop(): expected ok=%v, got %v", len(model) > 0, ok)
+			}
+			if !ok {
+				continue
+			}
+			if item != model[0] {
+				t.Fatalf("Pop(): expected %d, got %d", model[0], item)
+			}
+			q, model = next, model[1:]
+		} else {
+			q, model = q.Push(i), append(slices.Clip(model), i)
+		}
+		if q.rearLen > q.frontLen {
+			t.Fatalf("Rear list longer than front list: %d > %d", q.rearLen, q.frontLen)
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, model) {
+			t.Fatalf("All(): expected %v, got %v", model, got)
+		}
+		if len(versions) < 64 {
+			versions, models = append(versions, q), append(models, model)
+		} else {
+			versions[v], models[v] = q, model
+		}
+	}
+}
+
+func TestPersistent_Conversion(t *testing.T) {
+	q := New[int]()
+	q.PushMany(1, 2, 3)
+	p := q.Persistent()
+	q.Pop()
+	q.Push(10)
+	if got := slices.Collect(p.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Persistent() after changing the queue: expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	_, p2, _ := p.Push(4).Pop()
+	p2 = p2.Push(5) // items in both the front and rear lists
+	m := p2.Queue()
+	m.Push(6)
+	if got := slices.Collect(m.All()); !slices.Equal(got, []int{2, 3, 4, 5, 6}) {
+		t.Errorf("Queue(): expected %v, got %v", []int{2, 3, 4, 5, 6}, got)
+	}
+	if p2.Len() != 4 {
+		t.Errorf("Len() after changing the mutable copy: expected %d, got %d", 4, p2.Len())
+	}
+
+	p3 := PersistentFromSeq(slices.Values([]int{7, 8}))
+	if got := slices.Collect(p3.All()); !slices.Equal(got, []int{7, 8}) {
+		t.Errorf("PersistentFromSeq(): expected %v, got %v", []int{7, 8}, got)
+	}
+}
+
+func TestPersistent_ConcurrentReaders(t *testing.T) {
+	base := PersistentFromSeq(slices.Values([]int{1, 2, 3}))
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			q := base
+			for i := 0; i < 1000; i++ {
+				q = q.Push(g)
+				_, q, _ = q.Pop()
+				if got := slices.Collect(base.All()); !slices.Equal(got, []int{1, 2, 3}) {
+					t.Errorf("All() of the shared version: expected %v, got %v", []int{1, 2, 3}, got)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -1,14 +1,18 @@
 package queue
 
+import "iter"
+
 // Queue is a generic, non-thread-safe FIFO (first-in-first-out) queue
-// implementation backed by a dynamically resizing slice.The zero value
-// of Queue[T] is ready to use without initialization
+// implementation backed by a dynamically resizing ring buffer. The zero
+// value of Queue[T] is ready to use without initialization
 //
 // Use New() or NewWithCapacity() if you prefer an explicit constructor
 // or want to set an initial capacity.
 // If you do need thread-safety, use the collections/concurrent/queue package instead.
 type Queue[T any] struct {
-	items           []T
+	items           []T // ring buffer; len(items) is the capacity
+	head            int // index of the front element
+	count           int // number of elements in the queue
 	initialCapacity int
 }
 
@@ -42,11 +46,27 @@ func New[T any]() *Queue[T] {
 //	s := queue.NewWithCapacity[int](10)
 func NewWithCapacity[T any](capacity int) *Queue[T] {
 	return &Queue[T]{
-		items:           make([]T, 0, capacity),
+		items:           make([]T, capacity),
 		initialCapacity: capacity,
 	}
 }
 
+// FromSeq creates a queue holding the values of seq in the order
+// they are produced, so the first value yielded is the front of the queue.
+// slices.Collect(q.All()) does the reverse, and FromSeq(slices.Values(items))
+// rebuilds the same queue from its result.
+//
+// Example:
+//
+//	q := queue.FromSeq(slices.Values([]int{1, 2, 3}))
+func FromSeq[T any](seq iter.Seq[T]) *Queue[T] {
+	q := New[T]()
+	for v := range seq {
+		q.Push(v)
+	}
+	return q
+}
+
 // PushMany pushes one or more items onto the queue in order.
 // Equivalent to calling Push repeatedly but more efficient
 // when adding multiple elements.
@@ -55,7 +75,13 @@ func NewWithCapacity[T any](capacity int) *Queue[T] {
 //
 //	q.PushMany(1, 2, 3)
 func (q *Queue[T]) PushMany(item ...T) {
-	q.items = append(q.items, item...)
+	if q.count+len(item) > len(q.items) {
+		q.grow(len(item))
+	}
+	tail := q.index(q.count)
+	n := copy(q.items[tail:], item)
+	copy(q.items, item[n:])
+	q.count += len(item)
 }
 
 // Push adds a single item to the end of the queue.
@@ -64,7 +90,11 @@ func (q *Queue[T]) PushMany(item ...T) {
 //
 //	q.Push(42)
 func (q *Queue[T]) Push(item T) {
-	q.items = append(q.items, item)
+	if q.count == len(q.items) {
+		q.grow(1)
+	}
+	q.items[q.index(q.count)] = item
+	q.count++
 }
 
 // Pop removes and returns the element in front of the queue.
@@ -77,15 +107,17 @@ func (q *Queue[T]) Push(item T) {
 //	value, ok := q.Pop()
 //	if ok { fmt.Println(value) }
 func (q *Queue[T]) Pop() (T, bool) {
-	if len(q.items) == 0 {
-		var zero T
+	var zero T
+	if q.count == 0 {
 		return zero, false
 	}
-	item := q.items[0]
-	q.items = q.items[1:]
+	item := q.items[q.head]
+	q.items[q.head] = zero // release the reference for the GC
+	q.head = q.index(1)
+	q.count--
 
 	// Reduce capacity if:
-	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
+	//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
 	//   - current capacity exceeds 2× the initial capacity (if any),
 	//   - and fewer than 12.5% of elements are in use (cap/8).
 	//
@@ -97,19 +129,17 @@ func (q *Queue[T]) Pop() (T, bool) {
 	// Why halve capacity?
 	//   Halving avoids repeated reallocations while still reclaiming
 	//   unused memory proportionally. It balances memory efficiency and speed.
-	capNow := cap(q.items)
+	capNow := len(q.items)
 	if capNow > shrinkCapacityThreshold &&
 		(q.initialCapacity == 0 || capNow > q.initialCapacity*2) &&
-		len(q.items) < capNow/8 {
+		q.count < capNow/8 {
 
 		newCap := capNow / 2
 		if q.initialCapacity > 0 && newCap < q.initialCapacity {
 			newCap = q.initialCapacity
 		}
 		if newCap != capNow { // only shrink if capacity actually changes
-			newItems := make([]T, len(q.items), newCap)
-			copy(newItems, q.items)
-			q.items = newItems
+			q.resize(newCap)
 		}
 	}
 
@@ -123,11 +153,11 @@ func (q *Queue[T]) Pop() (T, bool) {
 //
 //	value, ok := q.Peek()
 func (q *Queue[T]) Peek() (T, bool) {
-	if len(q.items) == 0 {
+	if q.count == 0 {
 		var zero T
 		return zero, false
 	}
-	return q.items[0], true
+	return q.items[q.head], true
 }
 
 // Len returns the current number of items in the queue.
@@ -136,21 +166,23 @@ func (q *Queue[T]) Peek() (T, bool) {
 //
 //	n := s.Len()
 func (q *Queue[T]) Len() int {
-	return len(q.items)
+	return q.count
 }
 
 // Reset clears all items but keeps the current capacity
-// of the underlying slice. This is faster than Clear()
+// of the underlying buffer. This is faster than Clear()
 // when you expect to reuse the same queue size.
 //
 // Example:
 //
 //	q.Reset()
 func (q *Queue[T]) Reset() {
-	q.items = q.items[:0]
+	clear(q.items)
+	q.head = 0
+	q.count = 0
 }
 
-// Clear removes all items and reallocates a slice with
+// Clear removes all items and reallocates a buffer with
 // the initial capacity (if any). Use this to shrink the
 // backing array explicitly.
 //
@@ -158,5 +190,90 @@ func (q *Queue[T]) Reset() {
 //
 //	q.Clear()
 func (q *Queue[T]) Clear() {
-	q.items = make([]T, 0, q.initialCapacity)
+	q.items = make([]T, q.initialCapacity)
+	q.head = 0
+	q.count = 0
+}
+
+// All returns an iterator over the items of the queue in FIFO order,
+// from front to back, without removing them. The queue must not be
+// modified while the iteration is in progress.
+//
+// Example:
+//
+//	for v := range q.All() { fmt.Println(v) }
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.count; i++ {
+			if !yield(q.items[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue in reverse
+// order, from back to front, without removing them. The queue must not
+// be modified while the iteration is in progress.
+//
+// Example:
+//
+//	for v := range q.Backward() { fmt.Println(v) }
+func (q *Queue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := q.count - 1; i >= 0; i-- {
+			if !yield(q.items[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the position in the ring buffer of the element
+// that is offset places behind the front of the queue.
+func (q *Queue[T]) index(offset int) int {
+	i := q.head + offset
+	if i >= len(q.items) {
+		i -= len(q.items)
+	}
+	return i
+}
+
+// grow makes room for at least n more elements by doubling the
+// capacity, or more if a single PushMany needs it.
+func (q *Queue[T]) grow(n int) {
+	q.resize(max(len(q.items)*2, q.count+n))
+}
+
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the front of the queue is at index 0.
+func (q *Queue[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if q.count > 0 {
+		n := copy(newItems, q.items[q.head:min(q.head+q.count, len(q.items))])
+		copy(newItems[n:], q.items[:q.count-n])
+	}
+	q.items = newItems
+	q.head = 0
+}
+
+// ordered returns a copy of the items of the queue in FIFO order.
+func (q *Queue[T]) ordered() []T {
+	items := make([]T, q.count)
+	if q.count > 0 {
+		n := copy(items, q.items[q.head:min(q.head+q.count, len(q.items))])
+		copy(items[n:], q.items[:q.count-n])
+	}
+	return items
+}
+
+// replace makes items the contents of the queue, the first item becoming
+// the front of the queue. The buffer keeps at least the initial capacity.
+func (q *Queue[T]) replace(items []T) {
+	if len(items) < q.initialCapacity {
+		items = append(make([]T, 0, q.initialCapacity), items...)
+	}
+	q.items = items[:cap(items)]
+	q.head = 0
+	q.count = len(items)
 }
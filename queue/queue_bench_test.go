@@ -137,3 +137,95 @@ func BenchmarkQueue_Clear(b *testing.B) {
 		q.Clear()
 	}
 }
+
+// BenchmarkQueue_SteadyPushPop benchmarks a queue that holds a constant
+// number of items while elements flow through it. The ring buffer reuses
+// freed slots so this should not allocate.
+func BenchmarkQueue_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := New[int]()
+	for i := 0; i < 1000; i++ {
+		q.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+		_, _ = q.Pop()
+	}
+}
+
+// BenchmarkQueue_SteadyPushPopPointer is BenchmarkQueue_SteadyPushPop
+// with a pointer element type, the case where popped slots must be zeroed.
+func BenchmarkQueue_SteadyPushPopPointer(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	q := New[*int]()
+	v := new(int)
+	for i := 0; i < 1000; i++ {
+		q.Push(v)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(v)
+		_, _ = q.Pop()
+	}
+}
+
+func BenchmarkQueue_MarshalBinary100K(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 100_000; i++ {
+		s.Push(i)
+	}
+	for b.Loop() {
+		_, _ = s.MarshalBinary()
+	}
+}
+
+func BenchmarkQueue_MarshalJSON100K(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 100_000; i++ {
+		s.Push(i)
+	}
+	for b.Loop() {
+		_, _ = s.MarshalJSON()
+	}
+}
+
+func BenchmarkQueue_UnmarshalBinary100K(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	for i := 0; i < 100_000; i++ {
+		s.Push(i)
+	}
+	data, _ := s.MarshalBinary()
+	for b.Loop() {
+		_ = s.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkPersistent_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	var q Persistent[int]
+	for b.Loop() {
+		q = q.Push(1)
+	}
+}
+
+func BenchmarkPersistent_SteadyPushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	var q Persistent[int]
+	for i := 0; i < 1000; i++ {
+		q = q.Push(i)
+	}
+	for b.Loop() {
+		_, q, _ = q.Push(1).Pop()
+	}
+}
@@ -2,6 +2,7 @@ package queue_test
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/khavishbhundoo/collections/queue"
 )
@@ -46,3 +47,40 @@ func ExampleQueue() {
 	// 0 false
 	// 1 true
 }
+
+func ExampleQueue_All() {
+	q := queue.FromSeq(slices.Values([]int{1, 2, 3}))
+
+	// Iterating does not remove the items from the queue
+	for v := range q.All() {
+		fmt.Println(v)
+	}
+	fmt.Println(q.Len())
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 3
+}
+
+func ExamplePersistent() {
+	var q queue.Persistent[int]
+	q1 := q.PushMany(1, 2, 3)
+	front, q2, _ := q1.Pop()
+	q3 := q2.Push(4)
+
+	fmt.Println(front)
+	fmt.Println(slices.Collect(q1.All()))
+	fmt.Println(slices.Collect(q3.All()))
+
+	m := q3.Queue() // a mutable copy
+	m.Push(5)
+	fmt.Println(m.Len(), q3.Len())
+
+	// Output:
+	// 1
+	// [1 2 3]
+	// [2 3 4]
+	// 4 3
+}
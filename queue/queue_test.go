@@ -1,11 +1,16 @@
 package queue
 
 import (
+	"slices"
 	"testing"
 )
 
 func TestQueue_New(t *testing.T) {
 	q := New[int]()
+	if cap(q.items) != 0 {
+		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(q.items))
+	}
+
 	q.Push(1)
 
 	r, ok := q.Pop()
@@ -15,10 +20,6 @@ func TestQueue_New(t *testing.T) {
 	if r != 1 {
 		t.Errorf("Pop(): expected %d, got %d", 1, r)
 	}
-
-	if cap(q.items) != 0 {
-		t.Errorf("Initial capacity: expected %d, got %d", 0, cap(q.items))
-	}
 }
 
 func TestQueue_NewWithCapacity(t *testing.T) {
@@ -163,15 +164,15 @@ func TestQueue_Shrink(t *testing.T) {
 	initialCap := 64
 	q := NewWithCapacity[int](initialCap)
 
-	// Fill the queue
-	for i := 0; i < 100; i++ {
+	// Fill the queue past twice its initial capacity
+	for i := 0; i < 200; i++ {
 		q.Push(i)
 	}
 
 	peakCap := cap(q.items)
 
 	// Pop most of the items to trigger shrink (less than 25% used)
-	for i := 0; i < 90; i++ {
+	for i := 0; i < 190; i++ {
 		_, ok := q.Pop()
 		if !ok {
 			t.Fatalf("Pop() failed at iteration %d", i)
@@ -188,7 +189,7 @@ func TestQueue_Shrink(t *testing.T) {
 	}
 
 	// Remaining elements should still be correct
-	for _, val := range []int{90, 91, 92, 93, 94, 95, 96, 97, 98, 99} {
+	for _, val := range []int{190, 191, 192, 193, 194, 195, 196, 197, 198, 199} {
 		r, ok := q.Pop()
 		if !ok || r != val {
 			t.Errorf("Expected Pop() to return %d, got %d", val, r)
@@ -200,6 +201,20 @@ func TestQueue_Shrink(t *testing.T) {
 	}
 }
 
+func TestQueue_ShrinkKeepsTwiceInitialCapacity(t *testing.T) {
+	// A buffer that has only doubled from its initial capacity is not shrunk
+	q := NewWithCapacity[int](64)
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < 95; i++ {
+		q.Pop()
+	}
+	if cap(q.items) != 128 {
+		t.Errorf("Expected capacity to stay at %d, got %d", 128, cap(q.items))
+	}
+}
+
 func TestQueue_GenericType(t *testing.T) {
 	q := New[string]()
 	q.Push("foo")
@@ -215,3 +230,98 @@ func TestQueue_GenericType(t *testing.T) {
 		t.Errorf("Pop(): expected 'bar', got '%s'", r)
 	}
 }
+
+func TestQueue_WrapAround(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(1, 2, 3)
+	q.Pop()
+	q.Pop()
+
+	// The tail wraps to the start of the buffer without growing it
+	q.PushMany(4, 5, 6)
+	if cap(q.items) != 4 {
+		t.Errorf("Capacity after wrap: expected %d, got %d", 4, cap(q.items))
+	}
+
+	// Growing a wrapped buffer must keep FIFO order
+	q.PushMany(7, 8)
+	for _, val := range []int{3, 4, 5, 6, 7, 8} {
+		r, ok := q.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+}
+
+func TestQueue_PopReleasesReference(t *testing.T) {
+	q := NewWithCapacity[*int](4)
+	v := 1
+	q.Push(&v)
+	q.Pop()
+
+	for i, p := range q.items {
+		if p != nil {
+			t.Errorf("Slot %d still references a popped element", i)
+		}
+	}
+}
+
+func TestQueue_SteadyPushPopNoAllocs(t *testing.T) {
+	q := NewWithCapacity[int](8)
+	q.PushMany(1, 2, 3)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		q.Push(1)
+		q.Pop()
+	})
+	if allocs != 0 {
+		t.Errorf("Push/Pop: expected 0 allocations, got %v", allocs)
+	}
+}
+
+func TestQueue_All(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.PushMany(0, 1, 2)
+	q.Pop()
+	q.PushMany(3, 4) // wraps around the ring buffer
+
+	got := slices.Collect(q.All())
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3, 4}, got)
+	}
+	if q.Len() != 4 {
+		t.Errorf("Len() after All(): expected %d, got %d", 4, q.Len())
+	}
+
+	got = slices.Collect(q.Backward())
+	if !slices.Equal(got, []int{4, 3, 2, 1}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{4, 3, 2, 1}, got)
+	}
+
+	for v := range q.All() {
+		if v == 2 {
+			break
+		}
+		if v > 2 {
+			t.Errorf("All(): iteration continued after break, got %d", v)
+		}
+	}
+
+	var zero Queue[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value queue: unexpected %d", v)
+	}
+}
+
+func TestQueue_FromSeq(t *testing.T) {
+	q := FromSeq(slices.Values([]int{1, 2, 3}))
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("slices.Collect(All()): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	for _, val := range []int{1, 2, 3} {
+		r, ok := q.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package set
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The elements are written
+// in unspecified order in a compact versioned format.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// MarshalBinary and GobEncode have value receivers so that a Set embedded
+// by value in another struct is encoded even when it is not addressable.
+func (s Set[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, s.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the set with data encoded by MarshalBinary. On error the
+// set is not modified.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(items)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (s Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
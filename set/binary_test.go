@@ -0,0 +1,61 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/khavishbhundoo/collections/codec"
+)
+
+func TestSet_Binary(t *testing.T) {
+	s := New[int]()
+	s.AddMany(3, 1, 2)
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+	decoded := New[int]()
+	decoded.Add(9)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): unexpected error %v", err)
+	}
+	if got := slices.Sorted(decoded.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("UnmarshalBinary(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	// Invalid data leaves the set untouched
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, codec.ErrCorrupt) {
+		t.Errorf("UnmarshalBinary() of truncated data: expected %v, got %v", codec.ErrCorrupt, err)
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Len(): expected %d, got %d", 3, decoded.Len())
+	}
+}
+
+func TestSet_Gob(t *testing.T) {
+	type checkpoint struct {
+		Name  string
+		Items Set[string]
+	}
+	in := checkpoint{Name: "cp", Items: Set[string]{}}
+	in.Items.AddMany("c", "a", "b")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode(): unexpected error %v", err)
+	}
+	var out checkpoint
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode(): unexpected error %v", err)
+	}
+	if out.Name != "cp" {
+		t.Errorf("Decode(): expected name %q, got %q", "cp", out.Name)
+	}
+	if got := slices.Sorted(out.Items.All()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Decode(): expected %v, got %v", []string{"a", "b", "c"}, got)
+	}
+}
@@ -0,0 +1,50 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// MarshalJSON implements json.Marshaler. The set is encoded as a JSON
+// array of its elements, in unspecified order unless SortJSON was called.
+// An empty set encodes as [].
+//
+// MarshalJSON has a value receiver so that a Set held by value in another
+// struct is encoded even when that struct is not addressable. Embedding a
+// Set in a struct promotes MarshalJSON, which then encodes the whole
+// struct as the set, as with any embedded json.Marshaler; use a named
+// field to keep the other fields.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := s.values()
+	if s.jsonCompare != nil {
+		slices.SortFunc(items, s.jsonCompare)
+	}
+	return json.Marshal(items)
+}
+
+// SortJSON makes MarshalJSON encode the elements in ascending order as
+// decided by compare, so that equal sets always produce the same bytes,
+// wherever the set is encoded. Use cmp.Compare for ordered elements, or
+// nil to go back to unspecified order. The setting is kept by Reset,
+// Clear and UnmarshalJSON, but not passed on to the sets returned by
+// Union and the other set operations.
+func (s *Set[T]) SortJSON(compare func(a, b T) int) {
+	s.jsonCompare = compare
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the set with the elements of a JSON array; duplicates are ignored.
+// As is conventional, a JSON null leaves the set unchanged. On error the
+// set is not modified.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	s.replace(values)
+	return nil
+}
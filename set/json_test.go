@@ -0,0 +1,113 @@
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"testing"
+)
+
+func TestSet_MarshalJSON(t *testing.T) {
+	s := New[string]()
+	s.AddMany("b", "c", "a")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Fatalf("Marshal() produced invalid JSON %s: %v", data, err)
+	}
+	if len(values) != 3 {
+		t.Errorf("Marshal(): expected %d elements, got %s", 3, data)
+	}
+
+	s.SortJSON(cmp.Compare[string])
+	sorted, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() after SortJSON(): unexpected error %v", err)
+	}
+	if string(sorted) != `["a","b","c"]` {
+		t.Errorf("Marshal() after SortJSON(): expected %s, got %s", `["a","b","c"]`, sorted)
+	}
+
+	var empty Set[int]
+	data, _ = json.Marshal(empty)
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty set: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestSet_UnmarshalJSON(t *testing.T) {
+	type config struct {
+		Tags Set[string] `json:"tags"`
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"tags":["x","y","x"]}`), &c); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if c.Tags.Len() != 2 || !c.Tags.Contains("x") || !c.Tags.Contains("y") {
+		t.Errorf("Unmarshal(): expected {x, y}, got %d elements", c.Tags.Len())
+	}
+
+	if err := json.Unmarshal([]byte(`{"tags":null}`), &c); err != nil {
+		t.Errorf("Unmarshal(null): unexpected error %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"tags":[1]}`), &c); err == nil {
+		t.Errorf("Unmarshal() of invalid input: expected error, got nil")
+	}
+	if c.Tags.Len() != 2 {
+		t.Errorf("Set must be unchanged, got Len() = %d", c.Tags.Len())
+	}
+
+	// The decoded set replaces the previous contents
+	if err := json.Unmarshal([]byte(`{"tags":["z"]}`), &c); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	if c.Tags.Len() != 1 || !c.Tags.Contains("z") {
+		t.Errorf("Unmarshal(): expected {z}, got %d elements", c.Tags.Len())
+	}
+}
+
+func TestSet_MarshalJSONField(t *testing.T) {
+	type config struct {
+		Name string      `json:"name"`
+		Tags Set[string] `json:"tags"`
+	}
+	var c config
+	c.Name = "app"
+	c.Tags.SortJSON(cmp.Compare[string])
+	c.Tags.AddMany("b", "c", "a")
+
+	// c is passed by value, so the set is not addressable, and sorting
+	// applies to the field as well
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if want := `{"name":"app","tags":["a","b","c"]}`; string(data) != want {
+		t.Errorf("Marshal(): expected %s, got %s", want, data)
+	}
+
+	// The setting survives decoding into the set
+	if err := json.Unmarshal([]byte(`{"tags":["z","y"]}`), &c); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	data, _ = json.Marshal(c.Tags)
+	if string(data) != `["y","z"]` {
+		t.Errorf("Marshal() after Unmarshal(): expected %s, got %s", `["y","z"]`, data)
+	}
+
+	// An embedded set encodes as the whole struct
+	type embedded struct {
+		Set[string]
+		Name string
+	}
+	var e embedded
+	e.Name = "ignored"
+	e.Add("a")
+	data, _ = json.Marshal(e)
+	if string(data) != `["a"]` {
+		t.Errorf("Marshal() of embedding struct: expected %s, got %s", `["a"]`, data)
+	}
+}
@@ -1,5 +1,7 @@
 package set
 
+import "iter"
+
 // Set is a generic, non-thread-safe set implementation backed by a map[T]struct{}.
 // It stores unique elements of type T. The zero value of Set[T] is ready to use
 // without initialization.
@@ -9,6 +11,7 @@ package set
 type Set[T comparable] struct {
 	items           map[T]struct{}
 	initialCapacity int
+	jsonCompare     func(a, b T) int // set by SortJSON
 }
 
 // New creates an empty set of type T with no pre-allocated capacity.
@@ -29,6 +32,16 @@ func NewWithCapacity[T comparable](capacity int) *Set[T] {
 	}
 }
 
+// FromSeq creates a set holding the values of seq. Duplicates are ignored.
+// slices.Collect(s.All()) does the reverse, in no particular order.
+func FromSeq[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
 // Add inserts a value into the set. If the value already exists, it does nothing.
 // Initializes the underlying map if it is nil.
 func (s *Set[T]) Add(value T) {
@@ -91,3 +104,187 @@ func (s *Set[T]) Reset() {
 func (s *Set[T]) Clear() {
 	s.items = make(map[T]struct{}, s.initialCapacity)
 }
+
+// All returns an iterator over the elements of the set. The iteration
+// order is not specified and is not guaranteed to be the same from one
+// call to the next. Elements may be removed during iteration; elements
+// added during iteration may or may not be produced.
+// Safe to call on a zero-value Set.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new set holding the elements that are in s, in other, or in both.
+// Neither s nor other is modified. Either operand may be a zero-value Set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, max(len(s.items), len(other.items)))}
+	for v := range s.items {
+		result.items[v] = struct{}{}
+	}
+	for v := range other.items {
+		result.items[v] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns a new set holding the elements that are in both s and other.
+// Neither s nor other is modified. Either operand may be a zero-value Set.
+// The smaller of the two sets is iterated, so the cost is O(min(len(s), len(other))).
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s.items, other.items
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	result := &Set[T]{items: make(map[T]struct{}, len(small))}
+	for v := range small {
+		if _, ok := large[v]; ok {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set holding the elements of s that are not in other.
+// Neither s nor other is modified. Either operand may be a zero-value Set.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items))}
+	for v := range s.items {
+		if _, ok := other.items[v]; !ok {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set holding the elements that are in
+// either s or other, but not in both.
+// Neither s nor other is modified. Either operand may be a zero-value Set.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items)+len(other.items))}
+	for v := range s.items {
+		if _, ok := other.items[v]; !ok {
+			result.items[v] = struct{}{}
+		}
+	}
+	for v := range other.items {
+		if _, ok := s.items[v]; !ok {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// UnionWith adds every element of other to s. other is not modified.
+// Initializes the underlying map of s if it is nil.
+func (s *Set[T]) UnionWith(other *Set[T]) {
+	if s.items == nil {
+		s.items = make(map[T]struct{}, max(s.initialCapacity, len(other.items)))
+	}
+	for v := range other.items {
+		s.items[v] = struct{}{}
+	}
+}
+
+// IntersectWith removes from s every element that is not in other.
+// other is not modified. Safe on a zero-value Set.
+func (s *Set[T]) IntersectWith(other *Set[T]) {
+	if s == other {
+		return
+	}
+	for v := range s.items {
+		if _, ok := other.items[v]; !ok {
+			delete(s.items, v)
+		}
+	}
+}
+
+// ExceptWith removes from s every element that is in other.
+// other is not modified; if other is s, s becomes empty.
+// Safe on a zero-value Set.
+func (s *Set[T]) ExceptWith(other *Set[T]) {
+	if s == other {
+		clear(s.items)
+		return
+	}
+	if len(other.items) < len(s.items) {
+		for v := range other.items {
+			delete(s.items, v)
+		}
+		return
+	}
+	for v := range s.items {
+		if _, ok := other.items[v]; ok {
+			delete(s.items, v)
+		}
+	}
+}
+
+// IsSubsetOf reports whether every element of s is also in other.
+// Either operand may be a zero-value Set; the empty set is a subset of every set.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	return isSubset(s.items, other.items)
+}
+
+// IsSupersetOf reports whether every element of other is also in s.
+// Either operand may be a zero-value Set.
+func (s *Set[T]) IsSupersetOf(other *Set[T]) bool {
+	return isSubset(other.items, s.items)
+}
+
+// Equal reports whether s and other contain exactly the same elements.
+// Either operand may be a zero-value Set.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return len(s.items) == len(other.items) && isSubset(s.items, other.items)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+// Either operand may be a zero-value Set. The smaller of the two sets
+// is iterated.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	small, large := s.items, other.items
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for v := range small {
+		if _, ok := large[v]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset reports whether every key of a is also a key of b.
+func isSubset[T comparable](a, b map[T]struct{}) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for v := range a {
+		if _, ok := b[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// values returns a copy of the elements of the set.
+func (s *Set[T]) values() []T {
+	items := make([]T, 0, len(s.items))
+	for v := range s.items {
+		items = append(items, v)
+	}
+	return items
+}
+
+// replace makes values the elements of the set, ignoring duplicates.
+func (s *Set[T]) replace(values []T) {
+	s.items = make(map[T]struct{}, max(s.initialCapacity, len(values)))
+	for _, v := range values {
+		s.items[v] = struct{}{}
+	}
+}
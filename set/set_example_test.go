@@ -1,6 +1,8 @@
 package set_test
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 
 	"github.com/khavishbhundoo/collections/set"
@@ -54,3 +56,21 @@ func ExampleSet() {
 	// 3
 	// true
 }
+
+func ExampleSet_SortJSON() {
+	s := set.New[string]()
+	s.AddMany("pear", "apple", "fig")
+
+	// Sorted output is stable across runs, also when s is a struct field
+	s.SortJSON(cmp.Compare[string])
+	data, err := json.Marshal(s)
+	fmt.Println(string(data), err)
+
+	var decoded set.Set[string]
+	err = json.Unmarshal([]byte(`["fig","fig","kiwi"]`), &decoded)
+	fmt.Println(decoded.Len(), err)
+
+	// Output:
+	// ["apple","fig","pear"] <nil>
+	// 2 <nil>
+}
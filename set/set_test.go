@@ -1,6 +1,9 @@
 package set
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestSet_New(t *testing.T) {
 	s := New[int]()
@@ -151,3 +154,160 @@ func TestSet_Clear(t *testing.T) {
 		t.Errorf("Clear should allocate a new map, got nil")
 	}
 }
+
+func TestSet_All(t *testing.T) {
+	s := New[int]()
+	s.AddMany(1, 2, 3)
+
+	got := slices.Sorted(s.All())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	var zero Set[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value set: unexpected %d", v)
+	}
+}
+
+func TestSet_FromSeq(t *testing.T) {
+	s := FromSeq(slices.Values([]int{1, 2, 2, 3}))
+	if s.Len() != 3 {
+		t.Errorf("Expected size 3, got %d", s.Len())
+	}
+	for _, v := range []int{1, 2, 3} {
+		if !s.Contains(v) {
+			t.Errorf("Expected set to contain %d", v)
+		}
+	}
+}
+
+func TestSet_Union(t *testing.T) {
+	a := FromSeq(slices.Values([]int{1, 2, 3}))
+	b := FromSeq(slices.Values([]int{3, 4}))
+
+	got := slices.Sorted(a.Union(b).All())
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Union(): expected %v, got %v", []int{1, 2, 3, 4}, got)
+	}
+	if a.Len() != 3 || b.Len() != 2 {
+		t.Errorf("Union() should not modify its operands")
+	}
+
+	var zero Set[int]
+	if got := zero.Union(a); got.Len() != 3 {
+		t.Errorf("Union() with zero-value receiver: expected size 3, got %d", got.Len())
+	}
+	if got := a.Union(&zero); got.Len() != 3 {
+		t.Errorf("Union() with zero-value operand: expected size 3, got %d", got.Len())
+	}
+}
+
+func TestSet_Intersection(t *testing.T) {
+	a := FromSeq(slices.Values([]int{1, 2, 3, 4, 5}))
+	b := FromSeq(slices.Values([]int{4, 5, 6}))
+
+	for _, got := range []*Set[int]{a.Intersection(b), b.Intersection(a)} {
+		if s := slices.Sorted(got.All()); !slices.Equal(s, []int{4, 5}) {
+			t.Errorf("Intersection(): expected %v, got %v", []int{4, 5}, s)
+		}
+	}
+
+	var zero Set[int]
+	if got := zero.Intersection(a); got.Len() != 0 {
+		t.Errorf("Intersection() with zero-value receiver: expected size 0, got %d", got.Len())
+	}
+	if got := a.Intersection(&zero); got.Len() != 0 {
+		t.Errorf("Intersection() with zero-value operand: expected size 0, got %d", got.Len())
+	}
+}
+
+func TestSet_Difference(t *testing.T) {
+	a := FromSeq(slices.Values([]int{1, 2, 3}))
+	b := FromSeq(slices.Values([]int{2, 3, 4}))
+
+	if got := slices.Sorted(a.Difference(b).All()); !slices.Equal(got, []int{1}) {
+		t.Errorf("Difference(): expected %v, got %v", []int{1}, got)
+	}
+	if got := slices.Sorted(a.SymmetricDifference(b).All()); !slices.Equal(got, []int{1, 4}) {
+		t.Errorf("SymmetricDifference(): expected %v, got %v", []int{1, 4}, got)
+	}
+
+	var zero Set[int]
+	if got := a.Difference(&zero); got.Len() != 3 {
+		t.Errorf("Difference() with zero-value operand: expected size 3, got %d", got.Len())
+	}
+	if got := zero.SymmetricDifference(b); got.Len() != 3 {
+		t.Errorf("SymmetricDifference() with zero-value receiver: expected size 3, got %d", got.Len())
+	}
+}
+
+func TestSet_InPlace(t *testing.T) {
+	var s Set[int] // zero-value
+	s.UnionWith(FromSeq(slices.Values([]int{1, 2, 3, 4})))
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("UnionWith(): expected %v, got %v", []int{1, 2, 3, 4}, got)
+	}
+
+	s.IntersectWith(FromSeq(slices.Values([]int{2, 3, 4, 5})))
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("IntersectWith(): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+
+	s.ExceptWith(FromSeq(slices.Values([]int{3})))
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("ExceptWith(): expected %v, got %v", []int{2, 4}, got)
+	}
+
+	s.IntersectWith(&s)
+	if s.Len() != 2 {
+		t.Errorf("IntersectWith(self): expected size 2, got %d", s.Len())
+	}
+	s.ExceptWith(&s)
+	if s.Len() != 0 {
+		t.Errorf("ExceptWith(self): expected size 0, got %d", s.Len())
+	}
+
+	var zero Set[int]
+	zero.IntersectWith(&s)
+	zero.ExceptWith(&s)
+	if zero.Len() != 0 {
+		t.Errorf("Expected zero-value set to stay empty, got %d", zero.Len())
+	}
+}
+
+func TestSet_Relations(t *testing.T) {
+	a := FromSeq(slices.Values([]int{1, 2}))
+	b := FromSeq(slices.Values([]int{1, 2, 3}))
+	c := FromSeq(slices.Values([]int{4, 5}))
+	var zero Set[int]
+
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"a.IsSubsetOf(b)", a.IsSubsetOf(b), true},
+		{"b.IsSubsetOf(a)", b.IsSubsetOf(a), false},
+		{"a.IsSubsetOf(a)", a.IsSubsetOf(a), true},
+		{"zero.IsSubsetOf(a)", zero.IsSubsetOf(a), true},
+		{"a.IsSubsetOf(zero)", a.IsSubsetOf(&zero), false},
+		{"b.IsSupersetOf(a)", b.IsSupersetOf(a), true},
+		{"a.IsSupersetOf(b)", a.IsSupersetOf(b), false},
+		{"a.IsSupersetOf(zero)", a.IsSupersetOf(&zero), true},
+		{"a.Equal(b)", a.Equal(b), false},
+		{"a.Equal(a)", a.Equal(a), true},
+		{"a.Equal(copy)", a.Equal(FromSeq(slices.Values([]int{2, 1}))), true},
+		{"zero.Equal(New)", zero.Equal(New[int]()), true},
+		{"a.IsDisjoint(c)", a.IsDisjoint(c), true},
+		{"a.IsDisjoint(b)", a.IsDisjoint(b), false},
+		{"a.IsDisjoint(zero)", a.IsDisjoint(&zero), true},
+		{"zero.IsDisjoint(zero)", zero.IsDisjoint(&zero), true},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package stack
+
+import "github.com/khavishbhundoo/collections/internal/binenc"
+
+// MarshalBinary implements encoding.BinaryMarshaler. The items are written
+// from bottom to top in a compact versioned format.
+// See the collections/codec package for how elements are encoded;
+// element types without a built-in encoding need a registered codec.
+//
+// MarshalBinary and GobEncode have value receivers so that a Stack embedded
+// by value in another struct is encoded even when it is not addressable.
+func (s Stack[T]) MarshalBinary() ([]byte, error) {
+	return binenc.AppendSeq(nil, s.ordered())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents of the stack with data encoded by MarshalBinary. On error the
+// stack is not modified. A bounded stack keeps only the top MaxDepth
+// items, evicting the others.
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	items, err := binenc.DecodeSeq[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(items)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (s Stack[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (s *Stack[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,96 @@
+package stack
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sync/atomic"
+)
+
+// Checkpoint marks the depth of a Stack at the time Mark was called, so
+// that the stack can later be rolled back to it. The zero value is not a
+// valid checkpoint.
+type Checkpoint struct {
+	id    uint64 // unique across all stacks; increases with every Mark
+	depth int
+}
+
+// ErrStaleCheckpoint is returned by Rollback and Commit for a checkpoint
+// that is no longer valid: it was committed or rolled back already, or
+// the stack has since dropped below the checkpoint's depth, so the
+// elements under it are not the ones it marked. Checkpoints of another
+// stack are stale too.
+var ErrStaleCheckpoint = errors.New("stack: stale checkpoint")
+
+// checkpointIDs hands out the identifiers of checkpoints. It is shared
+// by all stacks so that a checkpoint is never valid for another stack.
+var checkpointIDs atomic.Uint64
+
+// Mark returns a checkpoint of the current depth of the stack. Until it
+// is committed or rolled back, it stays valid as long as the stack does
+// not drop below that depth: pushes and pops above it, or the stack
+// growing and shrinking its buffer, do not affect it.
+//
+// Checkpoints nest: rolling back or committing one also releases the
+// checkpoints taken after it, as when leaving an outer scope also leaves
+// the inner ones.
+func (s *Stack[T]) Mark() Checkpoint {
+	cp := Checkpoint{id: checkpointIDs.Add(1), depth: s.count}
+	s.marks = append(s.marks, cp)
+	return cp
+}
+
+// Rollback truncates the stack back to its depth when cp was taken,
+// discarding everything pushed since, and releases cp along with the
+// checkpoints taken after it. It returns ErrStaleCheckpoint, leaving the
+// stack unchanged, if cp is no longer valid.
+func (s *Stack[T]) Rollback(cp Checkpoint) error {
+	i, ok := s.findMark(cp)
+	if !ok {
+		return ErrStaleCheckpoint
+	}
+	s.releaseMarks(i)
+	s.truncate(cp.depth)
+	return nil
+}
+
+// Commit keeps everything pushed since cp was taken and releases cp
+// along with the checkpoints taken after it. It returns
+// ErrStaleCheckpoint if cp is no longer valid.
+func (s *Stack[T]) Commit(cp Checkpoint) error {
+	i, ok := s.findMark(cp)
+	if !ok {
+		return ErrStaleCheckpoint
+	}
+	s.releaseMarks(i)
+	return nil
+}
+
+// findMark returns the position of cp among the outstanding checkpoints.
+// The boolean return is false if cp is not one of them.
+func (s *Stack[T]) findMark(cp Checkpoint) (int, bool) {
+	i, ok := slices.BinarySearchFunc(s.marks, cp.id, func(m Checkpoint, id uint64) int {
+		return cmp.Compare(m.id, id)
+	})
+	return i, ok && s.marks[i] == cp
+}
+
+// releaseMarks releases the checkpoint at position i of the outstanding
+// checkpoints and all those taken after it.
+func (s *Stack[T]) releaseMarks(i int) {
+	clear(s.marks[i:])
+	s.marks = s.marks[:i]
+}
+
+// invalidate releases the checkpoints above depth, which become stale once
+// the stack drops below them. Checkpoints are taken at non-decreasing
+// depths unless invalidated, so they are the most recent ones.
+func (s *Stack[T]) invalidate(depth int) {
+	i := len(s.marks)
+	for i > 0 && s.marks[i-1].depth > depth {
+		i--
+	}
+	if i < len(s.marks) {
+		s.releaseMarks(i)
+	}
+}
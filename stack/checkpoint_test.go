@@ -0,0 +1,174 @@
+package stack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStack_Rollback(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2)
+	cp := s.Mark()
+	s.PushMany(3, 4)
+	s.Pop()
+	s.Push(5)
+	if err := s.Rollback(cp); err != nil {
+		t.Fatalf("Rollback(): unexpected error %v", err)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Rollback(): expected %v, got %v", []int{1, 2}, got)
+	}
+	for i := range s.items {
+		if i >= s.count && s.items[i] != 0 {
+			t.Errorf("Slot %d still holds a rolled back element", i)
+		}
+	}
+
+	// A checkpoint is released by Rollback
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Second Rollback(): expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	if err := s.Commit(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Commit() after Rollback(): expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+}
+
+func TestStack_Commit(t *testing.T) {
+	s := New[int]()
+	cp := s.Mark()
+	s.PushMany(1, 2)
+	if err := s.Commit(cp); err != nil {
+		t.Fatalf("Commit(): unexpected error %v", err)
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() after Commit(): expected %d, got %d", 2, s.Len())
+	}
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() after Commit(): expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	if err := s.Commit(Checkpoint{}); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Commit() of the zero Checkpoint: expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+}
+
+func TestStack_NestedCheckpoints(t *testing.T) {
+	s := New[string]()
+	global := s.Mark()
+	s.Push("x")
+	fn := s.Mark()
+	s.Push("y")
+	block := s.Mark()
+	s.Push("z")
+
+	// Rolling back the function scope releases the block scope too
+	if err := s.Rollback(fn); err != nil {
+		t.Fatalf("Rollback(): unexpected error %v", err)
+	}
+	if err := s.Rollback(block); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() of an inner checkpoint: expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []string{"x"}) {
+		t.Errorf("Backward(): expected %v, got %v", []string{"x"}, got)
+	}
+
+	// An inner commit leaves the outer checkpoint valid
+	inner := s.Mark()
+	s.Push("w")
+	if err := s.Commit(inner); err != nil {
+		t.Fatalf("Commit(): unexpected error %v", err)
+	}
+	if err := s.Rollback(global); err != nil {
+		t.Fatalf("Rollback(): unexpected error %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() after Rollback(): expected %d, got %d", 0, s.Len())
+	}
+}
+
+func TestStack_StaleCheckpoint(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+	cp := s.Mark()
+	s.Push(4)
+	s.Pop()
+	s.Pop() // drops below the checkpoint
+	s.PushMany(30, 40)
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() of a stale checkpoint: expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1, 2, 30, 40}) {
+		t.Errorf("Backward() after a failed Rollback(): expected %v, got %v", []int{1, 2, 30, 40}, got)
+	}
+
+	// A checkpoint at the current depth survives popping down to it
+	cp = s.Mark()
+	s.Push(50)
+	s.Pop()
+	if err := s.Commit(cp); err != nil {
+		t.Errorf("Commit(): unexpected error %v", err)
+	}
+
+	// Reset, Clear and decoding invalidate checkpoints above the bottom
+	for name, drop := range map[string]func(){
+		"Reset":         s.Reset,
+		"Clear":         s.Clear,
+		"UnmarshalJSON": func() { s.UnmarshalJSON([]byte("[1,2,3]")) },
+	} {
+		s.PushMany(1, 2, 3)
+		cp := s.Mark()
+		drop()
+		if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+			t.Errorf("Rollback() after %s(): expected %v, got %v", name, ErrStaleCheckpoint, err)
+		}
+	}
+
+	// Checkpoints of another stack are stale
+	other := New[int]()
+	other.Mark()
+	if err := s.Commit(other.Mark()); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Commit() of another stack's checkpoint: expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+}
+
+func TestStack_CheckpointEviction(t *testing.T) {
+	s := NewBounded[int](3)
+	bottom := s.Mark()
+	s.PushMany(1, 2)
+	cp := s.Mark()
+	s.Push(3)
+	s.Push(4) // evicts 1, which was below cp
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() after eviction: expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	if err := s.Rollback(bottom); err != nil {
+		t.Errorf("Rollback() of a checkpoint at depth 0: unexpected error %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() after Rollback(): expected %d, got %d", 0, s.Len())
+	}
+}
+
+func TestStack_CheckpointShrink(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 8; i++ {
+		s.Push(i)
+	}
+	cp := s.Mark()
+	for i := 0; i < 1024; i++ {
+		s.Push(i)
+	}
+	for i := 0; i < 1000; i++ {
+		s.Pop() // shrinks the buffer several times
+	}
+	capBefore := len(s.items)
+	if err := s.Rollback(cp); err != nil {
+		t.Fatalf("Rollback() after shrinking: unexpected error %v", err)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{0, 1, 2, 3, 4, 5, 6, 7}, got)
+	}
+	if len(s.items) >= capBefore {
+		t.Errorf("Expected Rollback() to shrink the buffer below %d, got %d", capBefore, len(s.items))
+	}
+}
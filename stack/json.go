@@ -0,0 +1,33 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. The stack is encoded as a JSON
+// array holding its items from bottom to top, the order in which they
+// were pushed, so the last element is the top. An empty stack encodes as [].
+//
+// MarshalJSON has a value receiver so that a Stack embedded by value in
+// another struct is encoded even when that struct is not addressable.
+func (s Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ordered())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// the stack with the items of a JSON array, pushed in order so that the
+// last element ends up on top. As is conventional, a JSON null leaves the
+// stack unchanged. On error the stack is not modified. A bounded stack
+// keeps only the top MaxDepth items, evicting the others.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	s.replace(items)
+	return nil
+}
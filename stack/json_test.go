@@ -0,0 +1,68 @@
+package stack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStack_MarshalJSON(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Marshal(): expected %s, got %s", "[1,2,3]", data)
+	}
+
+	var empty Stack[int]
+	data, _ = json.Marshal(&empty)
+	if string(data) != "[]" {
+		t.Errorf("Marshal() of empty stack: expected %s, got %s", "[]", data)
+	}
+}
+
+func TestStack_MarshalJSONEmbedded(t *testing.T) {
+	type state struct {
+		Undo Stack[string] `json:"undo"`
+	}
+	var st state
+	st.Undo.PushMany("a", "b")
+
+	// st is passed by value, so the stack is not addressable
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("Marshal(): unexpected error %v", err)
+	}
+	if string(data) != `{"undo":["a","b"]}` {
+		t.Errorf("Marshal(): expected %s, got %s", `{"undo":["a","b"]}`, data)
+	}
+}
+
+func TestStack_UnmarshalJSON(t *testing.T) {
+	s := New[int]()
+	s.Push(9)
+
+	if err := json.Unmarshal([]byte("[1,2,3]"), s); err != nil {
+		t.Fatalf("Unmarshal(): unexpected error %v", err)
+	}
+	for _, val := range []int{3, 2, 1} {
+		r, ok := s.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+
+	s.Push(9)
+	if err := json.Unmarshal([]byte("null"), s); err != nil {
+		t.Errorf("Unmarshal(null): unexpected error %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), s); err == nil {
+		t.Errorf("Unmarshal() of invalid input: expected error, got nil")
+	}
+	if r, _ := s.Peek(); s.Len() != 1 || r != 9 {
+		t.Errorf("Stack must be unchanged, got Len() = %d, Peek() = %d", s.Len(), r)
+	}
+}
@@ -0,0 +1,224 @@
+package stack
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// MinMax is a generic, non-thread-safe LIFO stack that also reports its
+// smallest and largest items in O(1) time. Every element records the
+// minimum and maximum of the stack from the bottom up to itself, so
+// Min and Max stay correct through Push, Pop and PushMany without
+// searching the stack. This costs three values of type T per element.
+//
+// Items are compared with the less function given at construction.
+// Use NewMinMax() or NewMinMaxWithCapacity() with a custom less function,
+// or NewMinMaxOrdered() for ordered types. Unlike Stack, the zero value
+// of MinMax is not ready to use: there is no ordering for an arbitrary T
+// to fall back on, so it can be popped and peeked as an empty stack, but
+// Push panics on it.
+type MinMax[T any] struct {
+	items           []minMaxEntry[T]
+	less            func(a, b T) bool
+	initialCapacity int
+}
+
+// minMaxEntry is an element of a MinMax stack with the minimum and
+// maximum of the elements from the bottom of the stack up to it.
+type minMaxEntry[T any] struct {
+	item, min, max T
+}
+
+// NewMinMax creates an empty min-max stack of type T ordered by less,
+// with no pre-allocated capacity. It panics if less is nil.
+func NewMinMax[T any](less func(a, b T) bool) *MinMax[T] {
+	mustLess(less)
+	return &MinMax[T]{
+		items:           []minMaxEntry[T]{},
+		less:            less,
+		initialCapacity: 0,
+	}
+}
+
+// NewMinMaxWithCapacity creates an empty min-max stack of type T ordered
+// by less, with a pre-allocated capacity. This avoids repeated allocations
+// if you know roughly how many elements you’ll push.
+// It panics if less is nil.
+func NewMinMaxWithCapacity[T any](capacity int, less func(a, b T) bool) *MinMax[T] {
+	mustLess(less)
+	return &MinMax[T]{
+		items:           make([]minMaxEntry[T], 0, capacity),
+		less:            less,
+		initialCapacity: capacity,
+	}
+}
+
+// NewMinMaxOrdered creates an empty min-max stack of an ordered type.
+func NewMinMaxOrdered[T cmp.Ordered]() *MinMax[T] {
+	return NewMinMax(cmp.Less[T])
+}
+
+// PushMany pushes one or more items onto the stack in order.
+// Equivalent to calling Push repeatedly but more efficient
+// when adding multiple elements.
+func (s *MinMax[T]) PushMany(item ...T) {
+	s.mustOrder()
+	s.items = slices.Grow(s.items, len(item))
+	for _, v := range item {
+		s.items = append(s.items, s.entry(len(s.items), v))
+	}
+}
+
+// Push adds a single item to the top of the stack.
+func (s *MinMax[T]) Push(item T) {
+	s.mustOrder()
+	s.items = append(s.items, s.entry(len(s.items), item))
+}
+
+// Pop removes and returns the top element of the stack.
+// The boolean return is false if the stack is empty.
+// The stack may shrink its capacity automatically if
+// it has grown significantly and is mostly empty.
+func (s *MinMax[T]) Pop() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	last := len(s.items) - 1
+	item := s.items[last].item
+	s.items[last] = minMaxEntry[T]{} // release the references for the GC
+	s.items = s.items[:last]
+
+	// Reduce capacity using the same policy as Stack.Pop: only when the
+	// slice is past the shrink threshold, more than twice the initial
+	// capacity (if any), and less than 1/8 full, halving it each time.
+	capNow := cap(s.items)
+	if capNow > shrinkCapacityThreshold &&
+		(s.initialCapacity == 0 || capNow > s.initialCapacity*2) &&
+		len(s.items) < capNow/8 {
+
+		newCap := capNow / 2
+		if s.initialCapacity > 0 && newCap < s.initialCapacity {
+			newCap = s.initialCapacity
+		}
+		if newCap != capNow { // only shrink if capacity actually changes
+			newItems := make([]minMaxEntry[T], len(s.items), newCap)
+			copy(newItems, s.items)
+			s.items = newItems
+		}
+	}
+
+	return item, true
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean return is false if the stack is empty.
+func (s *MinMax[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1].item, true
+}
+
+// Min returns the smallest item in the stack in O(1) time. If several
+// items are equally small, it returns the one closest to the bottom.
+// The boolean return is false if the stack is empty.
+func (s *MinMax[T]) Min() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1].min, true
+}
+
+// Max returns the largest item in the stack in O(1) time. If several
+// items are equally large, it returns the one closest to the bottom.
+// The boolean return is false if the stack is empty.
+func (s *MinMax[T]) Max() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1].max, true
+}
+
+// Len returns the current number of items in the stack.
+func (s *MinMax[T]) Len() int {
+	return len(s.items)
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying slice. This is faster than Clear()
+// when you expect to reuse the same stack size.
+func (s *MinMax[T]) Reset() {
+	clear(s.items)
+	s.items = s.items[:0]
+}
+
+// Clear removes all items and reallocates a slice with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (s *MinMax[T]) Clear() {
+	s.items = make([]minMaxEntry[T], 0, s.initialCapacity)
+}
+
+// All returns an iterator over the items of the stack from top to
+// bottom, the order in which Pop would return them, without removing
+// them. The stack must not be modified while the iteration is in progress.
+func (s *MinMax[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i].item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from bottom
+// to top, the order in which they were pushed, without removing them.
+// The stack must not be modified while the iteration is in progress.
+func (s *MinMax[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, e := range s.items {
+			if !yield(e.item) {
+				return
+			}
+		}
+	}
+}
+
+// entry returns the entry for item pushed at index i, combining it with
+// the minimum and maximum of the entries below.
+func (s *MinMax[T]) entry(i int, item T) minMaxEntry[T] {
+	if i == 0 {
+		return minMaxEntry[T]{item: item, min: item, max: item}
+	}
+	below := s.items[i-1]
+	e := minMaxEntry[T]{item: item, min: below.min, max: below.max}
+	if s.less(item, below.min) {
+		e.min = item
+	}
+	if s.less(below.max, item) {
+		e.max = item
+	}
+	return e
+}
+
+// mustLess panics if less is nil, so that a stack without an ordering
+// fails where it is created rather than on its first Push.
+func mustLess[T any](less func(a, b T) bool) {
+	if less == nil {
+		panic("stack: nil less function")
+	}
+}
+
+// mustOrder panics if the stack has no less function, which is the case
+// for the zero value.
+func (s *MinMax[T]) mustOrder() {
+	if s.less == nil {
+		panic("stack: Push on a MinMax without a less function; use NewMinMax or NewMinMaxOrdered")
+	}
+}
@@ -0,0 +1,187 @@
+package stack
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMinMax_PushPop(t *testing.T) {
+	s := NewMinMaxOrdered[int]()
+	if _, ok := s.Min(); ok {
+		t.Errorf("Min() on empty stack: expected NOK, got OK")
+	}
+	if _, ok := s.Max(); ok {
+		t.Errorf("Max() on empty stack: expected NOK, got OK")
+	}
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Pop() on empty stack: expected NOK, got OK")
+	}
+
+	s.Push(5)
+	s.PushMany(3, 8, 1)
+	s.Push(9)
+	tests := []struct{ top, min, max int }{
+		{9, 1, 9},
+		{1, 1, 8},
+		{8, 3, 8},
+		{3, 3, 5},
+		{5, 5, 5},
+	}
+	for _, tt := range tests {
+		if r, ok := s.Peek(); !ok || r != tt.top {
+			t.Errorf("Peek(): expected %d, got %d", tt.top, r)
+		}
+		if r, ok := s.Min(); !ok || r != tt.min {
+			t.Errorf("Min() with %d on top: expected %d, got %d", tt.top, tt.min, r)
+		}
+		if r, ok := s.Max(); !ok || r != tt.max {
+			t.Errorf("Max() with %d on top: expected %d, got %d", tt.top, tt.max, r)
+		}
+		if r, ok := s.Pop(); !ok || r != tt.top {
+			t.Errorf("Pop(): expected %d, got %d", tt.top, r)
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len(): expected %d, got %d", 0, s.Len())
+	}
+}
+
+func TestMinMax_NilLess(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"NewMinMax(nil)":                func() { NewMinMax[int](nil) },
+		"NewMinMaxWithCapacity(8, nil)": func() { NewMinMaxWithCapacity[int](8, nil) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "stack: nil less function" {
+					t.Errorf("%s: expected a panic about the nil less function, got %v", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestMinMax_Comparator(t *testing.T) {
+	// Order strings by length; ties keep the one closest to the bottom
+	s := NewMinMax(func(a, b string) bool { return len(a) < len(b) })
+	s.PushMany("bb", "a", "ccc", "z", "yyy")
+	if r, _ := s.Min(); r != "a" {
+		t.Errorf("Min(): expected %q, got %q", "a", r)
+	}
+	if r, _ := s.Max(); r != "ccc" {
+		t.Errorf("Max(): expected %q, got %q", "ccc", r)
+	}
+
+	var zero MinMax[string]
+	if _, ok := zero.Pop(); ok {
+		t.Errorf("Pop() on zero value: expected NOK, got OK")
+	}
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "less function") {
+			t.Errorf("Push() on zero value: expected a panic, got %v", r)
+		}
+	}()
+	zero.Push("a")
+}
+
+func TestMinMax_Random(t *testing.T) {
+	// Compare Min and Max with a scan of the stack after random operations
+	s := NewMinMaxOrdered[int]()
+	var model []int
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 5000; i++ {
+		switch op := r.IntN(10); {
+		case op < 4:
+			v := r.IntN(1000)
+			s.Push(v)
+			model = append(model, v)
+		case op < 5:
+			items := []int{r.IntN(1000), r.IntN(1000), r.IntN(1000)}
+			s.PushMany(items...)
+			model = append(model, items...)
+		case op < 9:
+			got, ok := s.Pop()
+			if ok != (len(model) > 0) {
+				t.Fatalf("Pop(): expected ok=%v, got %v", len(model) > 0, ok)
+			}
+			if ok {
+				if want := model[len(model)-1]; got != want {
+					t.Fatalf("Pop(): expected %d, got %d", want, got)
+				}
+				model = model[:len(model)-1]
+			}
+		default:
+			if r.IntN(50) == 0 {
+				s.Reset()
+				model = model[:0]
+			}
+		}
+		if len(model) == 0 {
+			continue
+		}
+		if got, _ := s.Min(); got != slices.Min(model) {
+			t.Fatalf("Min(): expected %d, got %d", slices.Min(model), got)
+		}
+		if got, _ := s.Max(); got != slices.Max(model) {
+			t.Fatalf("Max(): expected %d, got %d", slices.Max(model), got)
+		}
+	}
+}
+
+func TestMinMax_ResetClear(t *testing.T) {
+	s := NewMinMaxWithCapacity(5, cmp.Less[int])
+	s.PushMany(1, 2, 3)
+	s.Reset()
+	if _, ok := s.Min(); ok {
+		t.Errorf("Min() after Reset(): expected NOK, got OK")
+	}
+	if cap(s.items) != 5 {
+		t.Errorf("Capacity after Reset(): expected %d, got %d", 5, cap(s.items))
+	}
+	s.PushMany(4, 6)
+	if r, _ := s.Min(); r != 4 {
+		t.Errorf("Min() after Reset(): expected %d, got %d", 4, r)
+	}
+
+	for i := 0; i < 100; i++ {
+		s.Push(i)
+	}
+	s.Clear()
+	if _, ok := s.Max(); ok {
+		t.Errorf("Max() after Clear(): expected NOK, got OK")
+	}
+	if cap(s.items) != 5 {
+		t.Errorf("Capacity after Clear(): expected %d, got %d", 5, cap(s.items))
+	}
+}
+
+func TestMinMax_Shrink(t *testing.T) {
+	s := NewMinMaxOrdered[int]()
+	for i := 0; i < 1024; i++ {
+		s.Push(i)
+	}
+	for i := 0; i < 1020; i++ {
+		s.Pop()
+	}
+	if cap(s.items) >= 1024 {
+		t.Errorf("Expected capacity to shrink below %d, got %d", 1024, cap(s.items))
+	}
+	if r, _ := s.Max(); r != 3 {
+		t.Errorf("Max() after shrinking: expected %d, got %d", 3, r)
+	}
+}
+
+func TestMinMax_All(t *testing.T) {
+	s := NewMinMaxOrdered[int]()
+	s.PushMany(1, 2, 3)
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("All(): expected %v, got %v", []int{3, 2, 1}, got)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+}
@@ -0,0 +1,156 @@
+package stack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// stackOf returns a stack holding items, the last one on top, wrapped
+// around the end of its ring buffer so that the operations cross it.
+func stackOf(items ...int) *Stack[int] {
+	s := NewWithCapacity[int](len(items) + 2)
+	s.head = 3 // start near the end of the buffer
+	s.PushMany(items...)
+	return s
+}
+
+func TestStack_Ops(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s *Stack[int]) bool
+		want []int
+	}{
+		{"Dup", (*Stack[int]).Dup, []int{1, 2, 3, 3}},
+		{"Over", (*Stack[int]).Over, []int{1, 2, 3, 2}},
+		{"Swap", (*Stack[int]).Swap, []int{1, 3, 2}},
+		{"Rot", (*Stack[int]).Rot, []int{2, 3, 1}},
+		{"Pick(2)", func(s *Stack[int]) bool { return s.Pick(2) }, []int{1, 2, 3, 1}},
+		{"Roll(0)", func(s *Stack[int]) bool { return s.Roll(0) }, []int{1, 2, 3}},
+		{"Drop(2)", func(s *Stack[int]) bool { return s.Drop(2) }, []int{1}},
+		{"Drop(0)", func(s *Stack[int]) bool { return s.Drop(0) }, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		s := stackOf(1, 2, 3)
+		if !tt.op(s) {
+			t.Errorf("%s: expected true, got false", tt.name)
+		}
+		if got := slices.Collect(s.Backward()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	s := stackOf(1, 2, 3, 4, 5)
+	s.Roll(4)
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{2, 3, 4, 5, 1}) {
+		t.Errorf("Roll(4): expected %v, got %v", []int{2, 3, 4, 5, 1}, got)
+	}
+}
+
+func TestStack_OpsUnderflow(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s *Stack[int]) bool
+	}{
+		{"Dup", (*Stack[int]).Dup},
+		{"Over", (*Stack[int]).Over},
+		{"Swap", (*Stack[int]).Swap},
+		{"Rot", (*Stack[int]).Rot},
+		{"Pick(1)", func(s *Stack[int]) bool { return s.Pick(1) }},
+		{"Pick(-1)", func(s *Stack[int]) bool { return s.Pick(-1) }},
+		{"Roll(1)", func(s *Stack[int]) bool { return s.Roll(1) }},
+		{"Drop(2)", func(s *Stack[int]) bool { return s.Drop(2) }},
+		{"Drop(-1)", func(s *Stack[int]) bool { return s.Drop(-1) }},
+	}
+	for _, tt := range tests {
+		s := New[int]()
+		if tt.name != "Dup" {
+			s.Push(1)
+		}
+		if tt.op(s) {
+			t.Errorf("%s on %d element(s): expected false, got true", tt.name, s.Len())
+		}
+		if s.Len() > 1 {
+			t.Errorf("%s: expected the stack to be unchanged, got Len() = %d", tt.name, s.Len())
+		}
+	}
+}
+
+func TestStack_PeekNPopN(t *testing.T) {
+	s := stackOf(1, 2, 3, 4)
+	if got, ok := s.PeekN(3); !ok || !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PeekN(3): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+	if got, ok := s.PeekN(0); !ok || len(got) != 0 {
+		t.Errorf("PeekN(0): expected an empty slice, got %v", got)
+	}
+	if _, ok := s.PeekN(5); ok {
+		t.Errorf("PeekN(5) on 4 elements: expected NOK, got OK")
+	}
+	if s.Len() != 4 {
+		t.Errorf("Len() after PeekN(): expected %d, got %d", 4, s.Len())
+	}
+
+	if got, ok := s.PopN(3); !ok || !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PopN(3): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1}) {
+		t.Errorf("Backward() after PopN(): expected %v, got %v", []int{1}, got)
+	}
+	if _, ok := s.PopN(2); ok {
+		t.Errorf("PopN(2) on 1 element: expected NOK, got OK")
+	}
+	if _, ok := s.PopN(-1); ok {
+		t.Errorf("PopN(-1): expected NOK, got OK")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() after failed PopN(): expected %d, got %d", 1, s.Len())
+	}
+	for i := range s.items {
+		if i != s.head && s.items[i] != 0 {
+			t.Errorf("Slot %d still holds a popped element", i)
+		}
+	}
+}
+
+func TestStack_OpsBounded(t *testing.T) {
+	var evicted []int
+	s := NewBoundedWithEvict(3, func(item int) { evicted = append(evicted, item) })
+	s.PushMany(1, 2, 3)
+	s.Pick(2) // copies the bottom element before evicting it
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{2, 3, 1}) {
+		t.Errorf("Pick(2) on a full bounded stack: expected %v, got %v", []int{2, 3, 1}, got)
+	}
+	if !slices.Equal(evicted, []int{1}) {
+		t.Errorf("Evicted: expected %v, got %v", []int{1}, evicted)
+	}
+}
+
+func TestStack_OpsCheckpoints(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+	cp := s.Mark()
+	s.Dup()
+	s.Over()
+	s.Swap() // only touches elements above the checkpoint
+	if err := s.Commit(cp); err != nil {
+		t.Errorf("Commit() after Dup(), Over() and Swap(): unexpected error %v", err)
+	}
+
+	cp = s.Mark()
+	s.Roll(0) // moves nothing
+	if err := s.Commit(cp); err != nil {
+		t.Errorf("Commit() after Roll(0): unexpected error %v", err)
+	}
+
+	cp = s.Mark()
+	s.Rot() // reaches below the checkpoint
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() after Rot(): expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+	cp = s.Mark()
+	s.PopN(1)
+	if err := s.Rollback(cp); !errors.Is(err, ErrStaleCheckpoint) {
+		t.Errorf("Rollback() after PopN(): expected %v, got %v", ErrStaleCheckpoint, err)
+	}
+}
@@ -0,0 +1,140 @@
+package stack
+
+import (
+	"iter"
+	"slices"
+)
+
+// Persistent is a generic, immutable LIFO stack. Push and Pop leave the
+// stack they are called on unchanged and return a new version instead,
+// which shares all the elements it did not change with the old one, so
+// both take O(1) time and Push allocates a single node.
+// The zero value of Persistent[T] is an empty stack.
+//
+// Since a version never changes, it can be kept as long as needed, for
+// instance to backtrack in a search, and it can be read by any number of
+// goroutines without locking while others derive their own versions.
+// Sharing a variable that holds a version still needs synchronization,
+// such as an atomic.Pointer.
+//
+// Use Stack.Persistent() and Persistent.Stack() to convert to and from
+// the mutable Stack.
+type Persistent[T any] struct {
+	top *persistentNode[T]
+}
+
+// persistentNode is an element of a Persistent stack. It is never
+// modified once created, so any number of versions can share it.
+type persistentNode[T any] struct {
+	item T
+	next *persistentNode[T]
+	size int // number of nodes from this one to the bottom, inclusive
+}
+
+// PersistentFromSeq creates a persistent stack by pushing the values of
+// seq in the order they are produced, so the last value yielded ends up
+// on top.
+func PersistentFromSeq[T any](seq iter.Seq[T]) Persistent[T] {
+	var p Persistent[T]
+	for v := range seq {
+		p = p.Push(v)
+	}
+	return p
+}
+
+// Push returns a stack with item on top of the items of p.
+// p itself is not modified.
+func (p Persistent[T]) Push(item T) Persistent[T] {
+	return Persistent[T]{top: &persistentNode[T]{item: item, next: p.top, size: p.Len() + 1}}
+}
+
+// PushMany returns a stack with the items pushed onto p in order, so the
+// last item ends up on top. p itself is not modified.
+func (p Persistent[T]) PushMany(item ...T) Persistent[T] {
+	for _, v := range item {
+		p = p.Push(v)
+	}
+	return p
+}
+
+// Pop returns the top element of p and the stack of the elements below
+// it. p itself is not modified. The boolean return is false if p is
+// empty, in which case the returned stack is empty too.
+func (p Persistent[T]) Pop() (T, Persistent[T], bool) {
+	if p.top == nil {
+		var zero T
+		return zero, p, false
+	}
+	return p.top.item, Persistent[T]{top: p.top.next}, true
+}
+
+// Peek returns the top element of the stack.
+// The boolean return is false if the stack is empty.
+func (p Persistent[T]) Peek() (T, bool) {
+	if p.top == nil {
+		var zero T
+		return zero, false
+	}
+	return p.top.item, true
+}
+
+// Len returns the number of items in the stack in O(1) time.
+func (p Persistent[T]) Len() int {
+	if p.top == nil {
+		return 0
+	}
+	return p.top.size
+}
+
+// All returns an iterator over the items of the stack from top to
+// bottom, the order in which Pop would return them.
+func (p Persistent[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := p.top; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from bottom
+// to top, the order in which they were pushed. It copies the items first
+// since the nodes are linked from the top.
+func (p Persistent[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slices.Backward(p.topDown()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Stack returns a new mutable stack holding the items of p.
+func (p Persistent[T]) Stack() *Stack[T] {
+	items := p.topDown()
+	slices.Reverse(items)
+	s := New[T]()
+	s.replace(items)
+	return s
+}
+
+// Persistent returns a persistent stack holding the items of s.
+// Later changes to s do not affect it.
+func (s *Stack[T]) Persistent() Persistent[T] {
+	var p Persistent[T]
+	for v := range s.Backward() {
+		p = p.Push(v)
+	}
+	return p
+}
+
+// topDown returns a copy of the items of the stack from top to bottom.
+func (p Persistent[T]) topDown() []T {
+	items := make([]T, 0, p.Len())
+	for n := p.top; n != nil; n = n.next {
+		items = append(items, n.item)
+	}
+	return items
+}
@@ -0,0 +1,120 @@
+package stack
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistent_PushPop(t *testing.T) {
+	var empty Persistent[int] // zero-value
+	if _, _, ok := empty.Pop(); ok {
+		t.Errorf("Pop() on empty stack: expected NOK, got OK")
+	}
+	if _, ok := empty.Peek(); ok {
+		t.Errorf("Peek() on empty stack: expected NOK, got OK")
+	}
+
+	s1 := empty.Push(1)
+	s2 := s1.PushMany(2, 3)
+	if empty.Len() != 0 || s1.Len() != 1 || s2.Len() != 3 {
+		t.Errorf("Len(): expected 0, 1, 3, got %d, %d, %d", empty.Len(), s1.Len(), s2.Len())
+	}
+	if r, ok := s2.Peek(); !ok || r != 3 {
+		t.Errorf("Peek(): expected %d, got %d", 3, r)
+	}
+
+	r, s3, ok := s2.Pop()
+	if !ok || r != 3 {
+		t.Errorf("Pop(): expected %d, got %d", 3, r)
+	}
+	s4 := s3.Push(4)
+
+	// Every version keeps its own items
+	tests := []struct {
+		name string
+		s    Persistent[int]
+		want []int
+	}{
+		{"empty", empty, nil},
+		{"s1", s1, []int{1}},
+		{"s2", s2, []int{3, 2, 1}},
+		{"s3", s3, []int{2, 1}},
+		{"s4", s4, []int{4, 2, 1}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.s.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s.All(): expected %v, got %v", tt.name, tt.want, got)
+		}
+		if tt.s.Len() != len(tt.want) {
+			t.Errorf("%s.Len(): expected %d, got %d", tt.name, len(tt.want), tt.s.Len())
+		}
+	}
+
+	// s3 and s4 share the nodes of s1
+	if s3.top.next != s1.top || s4.top.next.next != s1.top {
+		t.Errorf("Expected derived versions to share the nodes of s1")
+	}
+}
+
+func TestPersistent_Iterators(t *testing.T) {
+	p := PersistentFromSeq(slices.Values([]int{1, 2, 3}))
+	if got := slices.Collect(p.All()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("All(): expected %v, got %v", []int{3, 2, 1}, got)
+	}
+	if got := slices.Collect(p.Backward()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	for v := range p.All() {
+		if v == 2 {
+			break
+		}
+		if v < 2 {
+			t.Errorf("All(): iteration continued after break, got %d", v)
+		}
+	}
+}
+
+func TestPersistent_Conversion(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+	p := s.Persistent()
+	s.Pop()
+	s.Push(10)
+	if got := slices.Collect(p.All()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Persistent() after changing the stack: expected %v, got %v", []int{3, 2, 1}, got)
+	}
+
+	m := p.Push(4).Stack()
+	m.Push(5)
+	if got := slices.Collect(m.All()); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Stack(): expected %v, got %v", []int{5, 4, 3, 2, 1}, got)
+	}
+	if p.Len() != 3 {
+		t.Errorf("Len() after changing the mutable copy: expected %d, got %d", 3, p.Len())
+	}
+	if (Persistent[int]{}).Stack().Len() != 0 {
+		t.Errorf("Stack() of an empty stack: expected an empty stack")
+	}
+}
+
+func TestPersistent_ConcurrentReaders(t *testing.T) {
+	base := PersistentFromSeq(slices.Values([]int{1, 2, 3}))
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			s := base
+			for i := 0; i < 1000; i++ {
+				s = s.Push(g)
+				_, s, _ = s.Pop()
+				if got := slices.Collect(base.All()); !slices.Equal(got, []int{3, 2, 1}) {
+					t.Errorf("All() of the shared version: expected %v, got %v", []int{3, 2, 1}, got)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
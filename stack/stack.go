@@ -1,15 +1,27 @@
 package stack
 
+import (
+	"iter"
+	"slices"
+)
+
 // Stack is a generic, non-thread-safe LIFO (last-in-first-out) stack
-// implementation backed by a dynamically resizing slice.The zero value
-// of Stack[T] is ready to use without initialization.
+// implementation backed by a dynamically resizing ring buffer. The zero
+// value of Stack[T] is ready to use without initialization.
 //
 // Use New() or NewWithCapacity() if you prefer an explicit constructor
-// or want to set an initial capacity.
+// or want to set an initial capacity. Use NewBounded() to limit the depth
+// of the stack: a push onto a full bounded stack drops the bottom element,
+// which suits undo histories that should only remember the latest changes.
 // If you do need thread-safety, use the collections/concurrent/stack package instead.
 type Stack[T any] struct {
-	items           []T
+	items           []T // ring buffer; len(items) is the capacity
+	head            int // index of the bottom element
+	count           int // number of elements in the stack
 	initialCapacity int
+	maxDepth        int // maximum number of elements; 0 means unbounded
+	onEvict         func(item T)
+	marks           []Checkpoint // outstanding checkpoints, oldest first
 }
 
 // shrinkCapacityThreshold defines the minimum slice capacity before
@@ -34,21 +46,85 @@ func New[T any]() *Stack[T] {
 // many elements you’ll push.
 func NewWithCapacity[T any](capacity int) *Stack[T] {
 	return &Stack[T]{
-		items:           make([]T, 0, capacity),
+		items:           make([]T, capacity),
 		initialCapacity: capacity,
 	}
 }
 
+// NewBounded creates an empty stack of type T that holds at most maxDepth
+// items. Pushing onto a full stack drops the bottom (oldest) element in
+// O(1) time to make room. A maxDepth of zero or less means the stack is
+// unbounded.
+func NewBounded[T any](maxDepth int) *Stack[T] {
+	return NewBoundedWithEvict[T](maxDepth, nil)
+}
+
+// NewBoundedWithEvict creates an empty stack of type T that holds at most
+// maxDepth items and calls onEvict with every element it drops to stay
+// within that depth, oldest first. It is not called for elements removed
+// by Pop, Reset or Clear.
+func NewBoundedWithEvict[T any](maxDepth int, onEvict func(item T)) *Stack[T] {
+	return &Stack[T]{
+		items:           []T{},
+		initialCapacity: 0,
+		maxDepth:        max(maxDepth, 0),
+		onEvict:         onEvict,
+	}
+}
+
+// FromSeq creates a stack by pushing the values of seq in the order
+// they are produced, so the last value yielded ends up on top.
+// slices.Collect(s.Backward()) does the reverse, and
+// FromSeq(slices.Values(items)) rebuilds the same stack from its result.
+func FromSeq[T any](seq iter.Seq[T]) *Stack[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Push(v)
+	}
+	return s
+}
+
 // PushMany pushes one or more items onto the stack in order.
 // Equivalent to calling Push repeatedly but more efficient
-// when adding multiple elements.
+// when adding multiple elements. On a bounded stack, the bottom
+// elements are dropped as needed, including the first of item
+// if there are more than the maximum depth.
 func (s *Stack[T]) PushMany(item ...T) {
-	s.items = append(s.items, item...)
+	if s.maxDepth > 0 {
+		if drop := s.count + len(item) - s.maxDepth; drop > 0 {
+			for range min(drop, s.count) {
+				s.evict()
+			}
+			if over := len(item) - s.maxDepth; over > 0 {
+				if s.onEvict != nil {
+					for _, v := range item[:over] {
+						s.onEvict(v)
+					}
+				}
+				item = item[over:]
+			}
+		}
+	}
+	if s.count+len(item) > len(s.items) {
+		s.grow(len(item))
+	}
+	top := s.index(s.count)
+	n := copy(s.items[top:], item)
+	copy(s.items, item[n:])
+	s.count += len(item)
 }
 
-// Push adds a single item to the top of the stack.
+// Push adds a single item to the top of the stack. On a full bounded
+// stack, the bottom element is dropped first.
 func (s *Stack[T]) Push(item T) {
-	s.items = append(s.items, item)
+	if s.maxDepth > 0 && s.count >= s.maxDepth {
+		s.evict()
+	}
+	if s.count == len(s.items) {
+		s.grow(1)
+	}
+	s.items[s.index(s.count)] = item
+	s.count++
 }
 
 // Pop removes and returns the top element of the stack.
@@ -56,15 +132,125 @@ func (s *Stack[T]) Push(item T) {
 // The stack may shrink its capacity automatically if
 // it has grown significantly and is mostly empty.
 func (s *Stack[T]) Pop() (T, bool) {
-	if len(s.items) == 0 {
+	var zero T
+	if s.count == 0 {
+		return zero, false
+	}
+	top := s.index(s.count - 1)
+	item := s.items[top]
+	s.items[top] = zero // release the reference for the GC
+	s.count--
+	s.invalidate(s.count)
+	s.shrink()
+	return item, true
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean return is false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if s.count == 0 {
 		var zero T
 		return zero, false
 	}
-	item := s.items[len(s.items)-1]
-	s.items = s.items[:len(s.items)-1]
+	return s.items[s.index(s.count-1)], true
+}
+
+// Len returns the current number of items in the stack.
+func (s *Stack[T]) Len() int {
+	return s.count
+}
+
+// MaxDepth returns the maximum number of items the stack holds,
+// or 0 if it is unbounded.
+func (s *Stack[T]) MaxDepth() int {
+	return s.maxDepth
+}
+
+// Reset clears all items but keeps the current capacity
+// of the underlying buffer. This is faster than Clear()
+// when you expect to reuse the same stack size.
+func (s *Stack[T]) Reset() {
+	clear(s.items)
+	s.head = 0
+	s.count = 0
+	s.invalidate(0)
+}
+
+// Clear removes all items and reallocates a buffer with
+// the initial capacity (if any). Use this to shrink the
+// backing array explicitly.
+func (s *Stack[T]) Clear() {
+	s.items = make([]T, s.initialCapacity)
+	s.head = 0
+	s.count = 0
+	s.invalidate(0)
+}
+
+// All returns an iterator over the items of the stack from top to
+// bottom, the order in which Pop would return them, without removing
+// them. The stack must not be modified while the iteration is in progress.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := s.count - 1; i >= 0; i-- {
+			if !yield(s.items[s.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from bottom
+// to top, the order in which they were pushed, without removing them.
+// The stack must not be modified while the iteration is in progress.
+func (s *Stack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < s.count; i++ {
+			if !yield(s.items[s.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the position in the ring buffer of the element
+// that is offset places above the bottom of the stack.
+func (s *Stack[T]) index(offset int) int {
+	i := s.head + offset
+	if i >= len(s.items) {
+		i -= len(s.items)
+	}
+	return i
+}
+
+// grow makes room for at least n more elements by doubling the
+// capacity, or more if a single PushMany needs it. A bounded stack
+// does not grow past its maximum depth.
+func (s *Stack[T]) grow(n int) {
+	newCap := max(len(s.items)*2, s.count+n)
+	if s.maxDepth > 0 {
+		newCap = min(newCap, max(s.maxDepth, s.count+n))
+	}
+	s.resize(newCap)
+}
 
+// resize moves the elements into a new buffer of the given capacity,
+// unwrapping them so that the bottom of the stack is at index 0.
+func (s *Stack[T]) resize(newCap int) {
+	newItems := make([]T, newCap)
+	if s.count > 0 {
+		n := copy(newItems, s.items[s.head:min(s.head+s.count, len(s.items))])
+		copy(newItems[n:], s.items[:s.count-n])
+	}
+	s.items = newItems
+	s.head = 0
+}
+
+// shrink halves the capacity of the buffer when the stack has grown
+// significantly and is mostly empty. Checkpoints record depths rather
+// than positions in the buffer, so shrinking does not affect them.
+func (s *Stack[T]) shrink() {
 	// Reduce capacity if:
-	//   - slice is larger than the shrink threshold (avoid tiny slice reallocations),
+	//   - buffer is larger than the shrink threshold (avoid tiny buffer reallocations),
 	//   - current capacity exceeds 2× the initial capacity (if any),
 	//   - and fewer than 12.5% of elements are in use (cap/8).
 	//
@@ -76,50 +262,64 @@ func (s *Stack[T]) Pop() (T, bool) {
 	// Why halve capacity?
 	//   Halving avoids repeated reallocations while still reclaiming
 	//   unused memory proportionally. It balances memory efficiency and speed.
-	capNow := cap(s.items)
+	capNow := len(s.items)
 	if capNow > shrinkCapacityThreshold &&
 		(s.initialCapacity == 0 || capNow > s.initialCapacity*2) &&
-		len(s.items) < capNow/8 {
+		s.count < capNow/8 {
 
 		newCap := capNow / 2
 		if s.initialCapacity > 0 && newCap < s.initialCapacity {
 			newCap = s.initialCapacity
 		}
 		if newCap != capNow { // only shrink if capacity actually changes
-			newItems := make([]T, len(s.items), newCap)
-			copy(newItems, s.items)
-			s.items = newItems
+			s.resize(newCap)
 		}
 	}
-
-	return item, true
 }
 
-// Peek returns the top element of the stack without removing it.
-// The boolean return is false if the stack is empty.
-func (s *Stack[T]) Peek() (T, bool) {
-	if len(s.items) == 0 {
-		var zero T
-		return zero, false
+// evict drops the bottom element of the stack in O(1) time and passes it
+// to the eviction callback, if any.
+func (s *Stack[T]) evict() {
+	var zero T
+	item := s.items[s.head]
+	s.items[s.head] = zero // release the reference for the GC
+	s.head = s.index(1)
+	s.count--
+	s.invalidate(0) // every checkpoint above the bottom lost an element below it
+	if s.onEvict != nil {
+		s.onEvict(item)
 	}
-	return s.items[len(s.items)-1], true
-}
-
-// Len returns the current number of items in the stack.
-func (s *Stack[T]) Len() int {
-	return len(s.items)
 }
 
-// Reset clears all items but keeps the current capacity
-// of the underlying slice. This is faster than Clear()
-// when you expect to reuse the same stack size.
-func (s *Stack[T]) Reset() {
-	s.items = s.items[:0]
+// ordered returns a copy of the items of the stack from bottom to top.
+func (s *Stack[T]) ordered() []T {
+	items := make([]T, s.count)
+	if s.count > 0 {
+		n := copy(items, s.items[s.head:min(s.head+s.count, len(s.items))])
+		copy(items[n:], s.items[:s.count-n])
+	}
+	return items
 }
 
-// Clear removes all items and reallocates a slice with
-// the initial capacity (if any). Use this to shrink the
-// backing array explicitly.
-func (s *Stack[T]) Clear() {
-	s.items = make([]T, 0, s.initialCapacity)
+// replace makes items the contents of the stack, from bottom to top,
+// and invalidates all checkpoints. The buffer keeps at least the initial
+// capacity. On a bounded stack, only the top items are kept and the
+// others are evicted, oldest first.
+func (s *Stack[T]) replace(items []T) {
+	if s.maxDepth > 0 && len(items) > s.maxDepth {
+		over := len(items) - s.maxDepth
+		if s.onEvict != nil {
+			for _, v := range items[:over] {
+				s.onEvict(v)
+			}
+		}
+		items = slices.Clone(items[over:]) // do not retain the evicted items
+	}
+	if len(items) < s.initialCapacity {
+		items = append(make([]T, 0, s.initialCapacity), items...)
+	}
+	s.items = items[:cap(items)]
+	s.head = 0
+	s.count = len(items)
+	s.marks = nil
 }
@@ -88,3 +88,81 @@ func BenchmarkStack_ResetAndPush10M(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkStack_BoundedPush(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewBounded[int](1000)
+	for b.Loop() {
+		s.Push(1)
+	}
+}
+
+func BenchmarkMinMax_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewMinMaxOrdered[int]()
+	i := 0
+	for b.Loop() {
+		s.Push(i)
+		i++
+	}
+}
+
+func BenchmarkMinMax_Pop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := NewMinMaxOrdered[int]()
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+	}
+	for b.Loop() {
+		s.Pop()
+	}
+}
+
+func BenchmarkPersistent_Push(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	var s Persistent[int]
+	for b.Loop() {
+		s = s.Push(1)
+	}
+}
+
+func BenchmarkPersistent_PushPop(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	var s Persistent[int]
+	for i := 0; i < 1000; i++ {
+		s = s.Push(i)
+	}
+	for b.Loop() {
+		_, s, _ = s.Push(1).Pop()
+	}
+}
+
+func BenchmarkStack_MarkRollback(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+	for b.Loop() {
+		cp := s.Mark()
+		s.PushMany(4, 5, 6)
+		s.Rollback(cp)
+	}
+}
+
+func BenchmarkStack_RollPopN(b *testing.B) {
+	b.ReportAllocs()
+	b.Cleanup(func() { runtime.GC() })
+	s := New[int]()
+	s.PushMany(1, 2, 3, 4)
+	for b.Loop() {
+		s.Roll(3)
+		s.Over()
+		s.PopN(2)
+		s.Push(0)
+	}
+}
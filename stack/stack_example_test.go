@@ -46,3 +46,104 @@ func ExampleStack() {
 	// 0 false
 	// 1 true
 }
+
+func ExampleNewBoundedWithEvict() {
+	// An undo history that remembers the last three edits
+	history := stack.NewBoundedWithEvict(3, func(edit string) {
+		fmt.Println("forgot", edit)
+	})
+	for _, edit := range []string{"type a", "type b", "delete", "paste"} {
+		history.Push(edit)
+	}
+	fmt.Println(history.Len())
+
+	edit, _ := history.Pop()
+	fmt.Println("undo", edit)
+
+	// Output:
+	// forgot type a
+	// 3
+	// undo paste
+}
+
+func ExampleMinMax() {
+	s := stack.NewMinMaxOrdered[int]()
+	s.PushMany(4, 2, 7)
+
+	lo, _ := s.Min()
+	hi, _ := s.Max()
+	fmt.Println(lo, hi)
+
+	s.Pop() // removing 7 makes 4 the largest again
+	hi, _ = s.Max()
+	fmt.Println(hi)
+
+	// Output:
+	// 2 7
+	// 4
+}
+
+func ExamplePersistent() {
+	// Backtracking keeps every earlier path without copying it
+	var path stack.Persistent[string]
+	path = path.Push("a")
+	left := path.Push("b")
+	right := path.Push("c").Push("d")
+
+	fmt.Println(left.Len(), right.Len(), path.Len())
+	top, rest, _ := right.Pop()
+	fmt.Println(top, rest.Len())
+
+	s := right.Stack() // a mutable copy
+	s.Push("e")
+	fmt.Println(s.Len(), right.Len())
+
+	// Output:
+	// 2 3 1
+	// d 2
+	// 4 3
+}
+
+func ExampleStack_Mark() {
+	// Variables of an interpreter, with one checkpoint per scope
+	vars := stack.New[string]()
+	vars.Push("x")
+
+	scope := vars.Mark()
+	vars.PushMany("i", "j")
+	fmt.Println(vars.Len())
+
+	// Leaving the scope drops its variables
+	if err := vars.Rollback(scope); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(vars.Len())
+
+	// A checkpoint can only be used once
+	fmt.Println(vars.Rollback(scope))
+
+	// Output:
+	// 3
+	// 1
+	// stack: stale checkpoint
+}
+
+func ExampleStack_Swap() {
+	// Evaluates the RPN program "3 4 over over + rot rot - swap"
+	// to compute both 3+4 and 3-4
+	s := stack.New[int]()
+	s.PushMany(3, 4)
+	s.Over()
+	s.Over()
+	args, _ := s.PopN(2)
+	s.Push(args[0] + args[1])
+	s.Rot()
+	s.Rot()
+	args, _ = s.PopN(2)
+	s.Push(args[0] - args[1])
+	s.Swap()
+	fmt.Println(s.PeekN(s.Len()))
+
+	// Output:
+	// [-1 7] true
+}
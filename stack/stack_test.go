@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -121,3 +122,137 @@ func TestStack_Clear(t *testing.T) {
 		t.Errorf("Capacity after Clear(): expected %d, got %d", 2, cap(s.items))
 	}
 }
+
+func TestStack_All(t *testing.T) {
+	s := New[int]()
+	s.PushMany(1, 2, 3)
+
+	got := slices.Collect(s.All())
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("All(): expected %v, got %v", []int{3, 2, 1}, got)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Len() after All(): expected %d, got %d", 3, s.Len())
+	}
+
+	got = slices.Collect(s.Backward())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+
+	for v := range s.All() {
+		if v == 2 {
+			break
+		}
+		if v < 2 {
+			t.Errorf("All(): iteration continued after break, got %d", v)
+		}
+	}
+
+	var zero Stack[int]
+	for v := range zero.All() {
+		t.Errorf("All() on zero-value stack: unexpected %d", v)
+	}
+}
+
+func TestStack_FromSeq(t *testing.T) {
+	s := FromSeq(slices.Values([]int{1, 2, 3}))
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("slices.Collect(Backward()): expected %v, got %v", []int{1, 2, 3}, got)
+	}
+	for _, val := range []int{3, 2, 1} {
+		r, ok := s.Pop()
+		if !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+}
+
+func TestStack_WrapAround(t *testing.T) {
+	s := NewBounded[int](4)
+	s.PushMany(0, 1, 2, 3)
+	s.Push(4) // drops 0, the top now sits where the bottom was
+	s.Push(5)
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{2, 3, 4, 5}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{2, 3, 4, 5}, got)
+	}
+	for _, val := range []int{5, 4} {
+		if r, ok := s.Pop(); !ok || r != val {
+			t.Errorf("Pop(): expected %d, got %d", val, r)
+		}
+	}
+	s.PushMany(6, 7)
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{7, 6, 3, 2}) {
+		t.Errorf("All(): expected %v, got %v", []int{7, 6, 3, 2}, got)
+	}
+	if r, ok := s.Peek(); !ok || r != 7 {
+		t.Errorf("Peek(): expected %d, got %d", 7, r)
+	}
+}
+
+func TestStack_Bounded(t *testing.T) {
+	var evicted []int
+	s := NewBoundedWithEvict(3, func(item int) { evicted = append(evicted, item) })
+	if s.MaxDepth() != 3 {
+		t.Errorf("MaxDepth(): expected %d, got %d", 3, s.MaxDepth())
+	}
+	for i := 1; i <= 5; i++ {
+		s.Push(i)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Len(): expected %d, got %d", 3, s.Len())
+	}
+	if len(s.items) != 3 {
+		t.Errorf("Capacity: expected %d, got %d", 3, len(s.items))
+	}
+	if !slices.Equal(evicted, []int{1, 2}) {
+		t.Errorf("Evicted: expected %v, got %v", []int{1, 2}, evicted)
+	}
+
+	// PushMany drops existing items first, then the oldest of its own
+	evicted = nil
+	s.PushMany(6, 7)
+	if !slices.Equal(evicted, []int{3, 4}) {
+		t.Errorf("Evicted by PushMany(): expected %v, got %v", []int{3, 4}, evicted)
+	}
+	evicted = nil
+	s.PushMany(8, 9, 10, 11, 12)
+	if !slices.Equal(evicted, []int{5, 6, 7, 8, 9}) {
+		t.Errorf("Evicted by PushMany(): expected %v, got %v", []int{5, 6, 7, 8, 9}, evicted)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{10, 11, 12}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{10, 11, 12}, got)
+	}
+
+	// Pop, Reset and Clear do not report to the callback
+	evicted = nil
+	s.Pop()
+	s.Reset()
+	s.Push(1)
+	s.Clear()
+	if len(evicted) != 0 {
+		t.Errorf("Evicted by Pop(), Reset() or Clear(): expected none, got %v", evicted)
+	}
+
+	// Decoding more items than fit keeps the top ones
+	if err := s.UnmarshalJSON([]byte("[1,2,3,4]")); err != nil {
+		t.Fatalf("UnmarshalJSON(): unexpected error %v", err)
+	}
+	if !slices.Equal(evicted, []int{1}) {
+		t.Errorf("Evicted by UnmarshalJSON(): expected %v, got %v", []int{1}, evicted)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("Backward(): expected %v, got %v", []int{2, 3, 4}, got)
+	}
+
+	// A non-positive depth means unbounded, as does the zero value
+	u := NewBounded[int](0)
+	u.PushMany(1, 2, 3, 4)
+	if u.Len() != 4 || u.MaxDepth() != 0 {
+		t.Errorf("NewBounded(0): expected an unbounded stack, got Len() = %d", u.Len())
+	}
+	var zero Stack[int]
+	if zero.MaxDepth() != 0 {
+		t.Errorf("MaxDepth() of zero value: expected %d, got %d", 0, zero.MaxDepth())
+	}
+}